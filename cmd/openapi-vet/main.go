@@ -0,0 +1,10 @@
+package main
+
+import (
+	"github.com/neticdk/go-openapi/pkg/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,227 @@
+// Package analyzer provides an analyzer reporting mistakes in openapi and schema godoc
+// directives. The analyzer can be run using go vet, as a standalone binary or by gopls.
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/neticdk/go-openapi/pkg/generator"
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	spacedDirectiveExp = regexp.MustCompile(`^//\s+((openapi|schema):(?:items\.)*(\w+))`)
+	unknownNameExp     = regexp.MustCompile(`^//(openapi|schema):(?:items\.)*(\w+)`)
+	pathParamExp       = regexp.MustCompile(`\{(\w+)\}`)
+)
+
+// Analyzer reports mistakes in openapi and schema directives
+var Analyzer = &analysis.Analyzer{
+	Name:      "openapi",
+	Doc:       "reports malformed openapi and schema directives, references to unknown components, path parameter mismatches and missing example files",
+	URL:       "https://github.com/neticdk/go-openapi",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(componentsFact)},
+}
+
+// componentsFact lists the names of the components declared by a package
+type componentsFact struct {
+	Names []string
+}
+
+func (*componentsFact) AFact() {}
+
+func (f *componentsFact) String() string {
+	return fmt.Sprintf("components(%s)", strings.Join(f.Names, ", "))
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	components := []string{}
+	for _, f := range pass.Files {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				d := checkSyntax(pass, c)
+				if d != nil && d.Name == generator.DirectiveComponent {
+					components = append(components, d.Args[1])
+				}
+			}
+		}
+	}
+	if len(components) > 0 {
+		sort.Strings(components)
+		pass.ExportPackageFact(&componentsFact{Names: components})
+	}
+
+	// Components may be declared in packages which are not imported, the check for unknown
+	// components is only done if any components are visible from the package
+	known := slices.Clone(components)
+	for _, pf := range pass.AllPackageFacts() {
+		if cf, ok := pf.Fact.(*componentsFact); ok {
+			known = append(known, cf.Names...)
+		}
+	}
+
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Doc == nil {
+				continue
+			}
+			checkOperation(pass, fd.Doc, known)
+		}
+	}
+
+	return nil, nil
+}
+
+// checkSyntax reports malformed directives returning the parsed directive if valid
+func checkSyntax(pass *analysis.Pass, c *ast.Comment) *generator.Directive {
+	if m := spacedDirectiveExp.FindStringSubmatchIndex(c.Text); m != nil {
+		name := c.Text[m[6]:m[7]]
+		if slices.Contains(generator.DirectiveNames(), name) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     c.Pos(),
+				End:     c.End(),
+				Message: fmt.Sprintf("directive %s must not have space after //", c.Text[m[2]:m[3]]),
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message:   "Remove space",
+					TextEdits: []analysis.TextEdit{{Pos: c.Pos() + 2, End: c.Pos() + token.Pos(m[2]), NewText: []byte{}}},
+				}},
+			})
+		}
+		return nil
+	}
+
+	d, err := generator.ParseDirective(c.Text)
+	if err == nil {
+		return d
+	}
+
+	diag := analysis.Diagnostic{Pos: c.Pos(), End: c.End(), Message: err.Error()}
+	if m := unknownNameExp.FindStringSubmatchIndex(c.Text); m != nil {
+		name := c.Text[m[4]:m[5]]
+		if suggestion := closest(name, generator.DirectiveNames()); suggestion != "" && suggestion != name {
+			diag.Message = fmt.Sprintf("%s - did you mean %s?", err, suggestion)
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("Replace with %s", suggestion),
+				TextEdits: []analysis.TextEdit{{Pos: c.Pos() + token.Pos(m[4]), End: c.Pos() + token.Pos(m[5]), NewText: []byte(suggestion)}},
+			}}
+		}
+	}
+	pass.Report(diag)
+	return nil
+}
+
+// checkOperation reports inconsistencies in the directives describing an operation
+func checkOperation(pass *analysis.Pass, doc *ast.CommentGroup, components []string) {
+	var operation *ast.Comment
+	var path string
+	pathParams := map[string]*ast.Comment{}
+	for _, c := range doc.List {
+		d, err := generator.ParseDirective(c.Text)
+		if err != nil || d == nil {
+			continue
+		}
+
+		switch d.Name {
+		case generator.DirectiveOperation:
+			operation, path = c, d.Args[0]
+		case generator.DirectiveParameter:
+			if d.Args[1] == "path" {
+				pathParams[d.Args[0]] = c
+			}
+		case generator.DirectiveResponseContent, generator.DirectiveRequestBody:
+			model := d.Args[2]
+			if d.Name == generator.DirectiveRequestBody {
+				model = d.Args[1]
+			}
+			if strings.Contains(model, "[") { // Instantiation of a generic type given as a Go type
+				if _, err := types.Eval(pass.Fset, pass.Pkg, c.Pos(), model); err != nil {
+					pass.Reportf(c.Pos(), "unknown type %s: %s", model, err)
+				}
+			} else if len(components) > 0 && !slices.Contains(components, model) {
+				pass.Reportf(c.Pos(), "unknown component %s", model)
+			}
+		case generator.DirectiveResponseExample:
+			file := filepath.Join(filepath.Dir(pass.Fset.File(c.Pos()).Name()), d.Args[2])
+			if _, err := os.Stat(file); err != nil {
+				pass.Reportf(c.Pos(), "example file %s not found", d.Args[2])
+			}
+		}
+	}
+	if operation == nil {
+		return
+	}
+
+	placeholders := []string{}
+	for _, m := range pathParamExp.FindAllStringSubmatch(path, -1) {
+		placeholders = append(placeholders, m[1])
+		if _, ok := pathParams[m[1]]; !ok {
+			pass.Report(analysis.Diagnostic{
+				Pos:     operation.Pos(),
+				End:     operation.End(),
+				Message: fmt.Sprintf("path parameter %s of %s is not described by an openapi:parameter directive", m[1], path),
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message:   fmt.Sprintf("Add parameter %s", m[1]),
+					TextEdits: []analysis.TextEdit{{Pos: operation.End(), End: operation.End(), NewText: []byte(fmt.Sprintf("\n//openapi:parameter %s path string", m[1]))}},
+				}},
+			})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(pathParams)) {
+		if c := pathParams[name]; !slices.Contains(placeholders, name) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     c.Pos(),
+				End:     c.End(),
+				Message: fmt.Sprintf("path parameter %s is not part of the path %s", name, path),
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message:   fmt.Sprintf("Remove parameter %s", name),
+					TextEdits: []analysis.TextEdit{{Pos: c.Pos(), End: c.End() + 1, NewText: []byte{}}},
+				}},
+			})
+		}
+	}
+}
+
+// closest returns the candidate with the smallest edit distance to the name if the distance
+// is small enough to be a likely typo
+func closest(name string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, c := range candidates {
+		if d := distance(strings.ToLower(name), strings.ToLower(c)); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	return best
+}
+
+// distance is the Levenshtein distance between the strings
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
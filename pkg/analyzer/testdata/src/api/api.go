@@ -0,0 +1,46 @@
+package api
+
+import _ "model"
+
+// GetModel gets a model
+//
+// want +3 `path parameter id of /models/{id} is not described by an openapi:parameter directive`
+// want +5 `example file examples/missing.json not found`
+//
+//openapi:operation /models/{id} GET
+//openapi:responseContent 200 application/json Model
+//openapi:responseExample 200 application/json examples/model.json
+//openapi:responseExample 200 application/json examples/missing.json
+func GetModel() {}
+
+// ReplaceModel replaces a model
+//
+// want +7 `path parameter name is not part of the path /models/{id}`
+// want +7 `unknown component Unknown`
+// want +7 `malformed directive openapi:response - expected //openapi:response <code> \["description"\]`
+// want +7 `unknown directive openapi:tga - did you mean tag\?`
+//
+//openapi:operation /models/{id} PUT
+//openapi:parameter id path string
+//openapi:parameter name path string
+//openapi:requestBody application/json Unknown
+//openapi:response ok
+//openapi:tga models
+func ReplaceModel() {}
+
+// Page is a page of items
+type Page[T any] struct {
+	Items []T `json:"items"`
+}
+
+// Item is an item of a page
+type Item struct{}
+
+// ListModels lists models
+//
+// want +3 `unknown type Page\[Missing\]: .*undefined: Missing`
+//
+//openapi:operation /models GET
+//openapi:responseContent 200 application/json Page[Missing]
+//openapi:responseContent 206 application/json Page[Item]
+func ListModels() {}
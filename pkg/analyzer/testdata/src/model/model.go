@@ -0,0 +1,22 @@
+package model // want package:"components\\(Model\\)"
+
+// Model is a component
+//
+//openapi:component schema Model
+type Model struct {
+	// Name of the model
+	//schema:example name
+	Name string `json:"name"`
+
+	// Size of the model
+	//schema:exampel 10 // want `unknown directive schema:exampel - did you mean example\?`
+	Size int `json:"size"`
+
+	// Kind of the model
+	// schema:format uri // want `directive schema:format must not have space after //`
+	Kind string `json:"kind"`
+
+	// IDs of related models
+	//schema:items.formt uuid // want `unknown directive schema:items.formt - did you mean format\?`
+	IDs []string `json:"ids"`
+}
@@ -0,0 +1,7 @@
+package standalone
+
+// GetModel refers to a component in a package which is not imported
+//
+//openapi:operation /models GET
+//openapi:responseContent 200 application/json Model
+func GetModel() {}
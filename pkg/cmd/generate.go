@@ -3,8 +3,12 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 
+	openapispec "github.com/go-openapi/spec"
+	"github.com/neticdk/go-openapi/pkg/diff"
 	"github.com/neticdk/go-openapi/pkg/generator"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -13,6 +17,15 @@ import (
 
 const (
 	generateOutput = "generate.output"
+	generateCheck  = "generate.check"
+
+	// Configuration keys of the generator flags shared by the commands generating the
+	// specification, the keys are prefixed by the name of the command
+	allowErrorsKey      = "allowErrors"
+	validateTagsKey     = "validateTags"
+	allOptionalKey      = "allOptional"
+	genericSeparatorKey = "genericSeparator"
+	typeMappingsKey     = "typeMappings"
 )
 
 var (
@@ -22,15 +35,15 @@ var (
 		Long:  "This command will render OpenAPI specification based on scanning given packages for godoc directives.",
 		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg := &packages.Config{
-				Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
-			}
-			pkgs, err := packages.Load(cfg, args...)
+			spec, err := generateSpec("generate", args)
 			if err != nil {
-				return fmt.Errorf("unable to load packages: %w", err)
+				return err
 			}
 
-			spec := generator.GenerateSpec(pkgs)
+			if viper.GetBool(generateCheck) {
+				cmd.SilenceUsage = true
+				return checkSpec(cmd.OutOrStdout(), viper.GetString(generateOutput), spec)
+			}
 
 			var file *os.File
 			if viper.GetString(generateOutput) == "-" {
@@ -56,6 +69,158 @@ var (
 func init() {
 	generateCmd.Flags().StringP("output", "o", "openapi.json", "Output file for openapi specification document")
 	viper.BindPFlag(generateOutput, generateCmd.Flags().Lookup("output"))
+	generateCmd.Flags().Bool("check", false, "Compare the generated specification with the existing output file without writing it and fail if it is out of date")
+	viper.BindPFlag(generateCheck, generateCmd.Flags().Lookup("check"))
+	addGeneratorFlags(generateCmd, "generate")
 
 	rootCmd.AddCommand(generateCmd)
 }
+
+// addGeneratorFlags adds the flags controlling loading of the packages and generation of
+// the specification binding them to configuration keys prefixed by the name of the command
+func addGeneratorFlags(cmd *cobra.Command, prefix string) {
+	cmd.Flags().Bool("allow-errors", false, "Generate specification even if packages fail to load or type-check")
+	viper.BindPFlag(prefix+"."+allowErrorsKey, cmd.Flags().Lookup("allow-errors"))
+	cmd.Flags().Bool("validate-tags", false, "Translate go-playground/validator validate struct tags to JSON schema keywords")
+	viper.BindPFlag(prefix+"."+validateTagsKey, cmd.Flags().Lookup("validate-tags"))
+	cmd.Flags().Bool("all-optional", false, "Render properties as optional unless marked by schema:required rather than inferring required properties")
+	viper.BindPFlag(prefix+"."+allOptionalKey, cmd.Flags().Lookup("all-optional"))
+	cmd.Flags().String("generic-separator", "", "Separator between the names of a generic type and its type arguments in component names of instantiations")
+	viper.BindPFlag(prefix+"."+genericSeparatorKey, cmd.Flags().Lookup("generic-separator"))
+	cmd.Flags().String("type-mappings", "", "File mapping Go types to schemas overriding the inferred schemas")
+	viper.BindPFlag(prefix+"."+typeMappingsKey, cmd.Flags().Lookup("type-mappings"))
+}
+
+// generateSpec loads the packages and generates the specification using the generator
+// flags of the command given by prefix in addition to the given options
+func generateSpec(prefix string, patterns []string, opts ...generator.Option) (*openapispec.Swagger, error) {
+	pkgs, err := loadPackages(patterns, viper.GetBool(prefix+"."+allowErrorsKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if viper.GetBool(prefix + "." + validateTagsKey) {
+		opts = append(opts, generator.WithValidateTags())
+	}
+	if viper.GetBool(prefix + "." + allOptionalKey) {
+		opts = append(opts, generator.WithAllOptional())
+	}
+	if sep := viper.GetString(prefix + "." + genericSeparatorKey); sep != "" {
+		opts = append(opts, generator.WithGenericSeparator(sep))
+	}
+	if file := viper.GetString(prefix + "." + typeMappingsKey); file != "" {
+		mappings, err := readTypeMappings(file)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithTypeMappings(mappings))
+	}
+	return generator.GenerateSpec(pkgs, opts...), nil
+}
+
+// loadPackages loads the packages printing any errors, errors will fail the loading
+// unless explicitly allowed
+func loadPackages(patterns []string, allowErrors bool) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load packages: %w", err)
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 && !allowErrors {
+		return nil, fmt.Errorf("packages contain %d error(s) - fix the errors or use --allow-errors to generate a possibly incomplete specification", n)
+	}
+	return pkgs, nil
+}
+
+// typeMapping maps a Go type given by import path and type name to a JSON type with an
+// optional format or an inline JSON schema
+type typeMapping struct {
+	Type   string `mapstructure:"type"`
+	Schema string `mapstructure:"schema"`
+}
+
+// readTypeMappings reads the type mappings from the file
+func readTypeMappings(file string) (map[string]*openapispec.Schema, error) {
+	cfg := struct {
+		TypeMappings []typeMapping `mapstructure:"typeMappings"`
+	}{}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("unable to read type mappings %s: %w", file, err)
+	}
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse type mappings %s: %w", file, err)
+	}
+
+	mappings := map[string]*openapispec.Schema{}
+	for _, m := range cfg.TypeMappings {
+		schema, err := generator.ParseTypeMapping(m.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type mapping of %s in %s: %w", m.Type, file, err)
+		}
+		mappings[m.Type] = schema
+	}
+	return mappings, nil
+}
+
+// checkSpec compares the generated specification semantically with the existing file
+// printing the changes if the file is out of date
+func checkSpec(out io.Writer, output string, spec *openapispec.Swagger) error {
+	if output == "-" {
+		return fmt.Errorf("checking the specification requires an output file")
+	}
+
+	existing, err := os.ReadFile(output)
+	if err != nil {
+		return fmt.Errorf("unable to read existing specification %s: %w", output, err)
+	}
+	generated, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("unable to encode openapi specification: %w", err)
+	}
+
+	changes, err := diff.Documents(existing, generated)
+	if err != nil {
+		return fmt.Errorf("unable to compare with existing specification %s: %w", output, err)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	paths, schemas := []string{}, []string{}
+	for _, c := range changes {
+		if len(c.Path) < 2 {
+			continue
+		}
+		if c.Path[0] == "paths" && !slices.Contains(paths, c.Path[1]) {
+			paths = append(paths, c.Path[1])
+		}
+		if c.Path[0] == "definitions" && !slices.Contains(schemas, c.Path[1]) {
+			schemas = append(schemas, c.Path[1])
+		}
+	}
+
+	fmt.Fprintf(out, "%s is out of date\n", output)
+	if len(paths) > 0 {
+		fmt.Fprintf(out, "\nChanged paths:\n")
+		for _, p := range paths {
+			fmt.Fprintf(out, "  %s\n", p)
+		}
+	}
+	if len(schemas) > 0 {
+		fmt.Fprintf(out, "\nChanged schemas:\n")
+		for _, s := range schemas {
+			fmt.Fprintf(out, "  %s\n", s)
+		}
+	}
+	fmt.Fprintf(out, "\nChanges:\n")
+	for _, c := range changes {
+		fmt.Fprintf(out, "  %s\n", c)
+	}
+
+	return fmt.Errorf("%s is out of date with %d change(s) - run generate to update", output, len(changes))
+}
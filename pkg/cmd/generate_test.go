@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTypeMappings(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "mappings.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`typeMappings:
+  - type: github.com/google/uuid.UUID
+    schema: string/uuid
+  - type: example.com/money.Amount
+    schema: '{"type": "integer", "minimum": 0}'
+`), 0o600))
+
+	mappings, err := readTypeMappings(file)
+	require.NoError(t, err)
+	assert.Len(t, mappings, 2)
+	assert.Equal(t, []string{"string"}, []string(mappings["github.com/google/uuid.UUID"].Type))
+	assert.Equal(t, "uuid", mappings["github.com/google/uuid.UUID"].Format)
+	if amount := mappings["example.com/money.Amount"]; assert.NotNil(t, amount) {
+		assert.Equal(t, []string{"integer"}, []string(amount.Type))
+		assert.Equal(t, 0.0, *amount.Minimum)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte(`typeMappings:
+  - type: example.com/money.Amount
+    schema: decimal
+`), 0o600))
+	_, err = readTypeMappings(invalid)
+	assert.ErrorContains(t, err, "invalid type mapping of example.com/money.Amount")
+
+	_, err = readTypeMappings(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "unable to read type mappings")
+}
+
+const fixturePackages = "../generator/fixture/..."
+
+// runGenerate runs the generate command returning the output and the error of the command
+func runGenerate(args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs(append([]string{"generate"}, args...))
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+// staleDocument writes a copy of the specification document leaving out a path and a schema
+func staleDocument(t *testing.T, file string) string {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	doc := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	delete(doc["paths"].(map[string]any), "/entities")
+	delete(doc["definitions"].(map[string]any), "Inner")
+	data, err = json.Marshal(doc)
+	require.NoError(t, err)
+	return writeDocument(t, string(data))
+}
+
+func TestGenerateCheck(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "openapi.json")
+	_, err := runGenerate("--check=false", "-o", file, fixturePackages)
+	require.NoError(t, err)
+
+	out, err := runGenerate("--check", "-o", file, fixturePackages)
+	assert.NoError(t, err)
+	assert.Empty(t, out)
+
+	stale := staleDocument(t, file)
+	out, err = runGenerate("--check", "-o", stale, fixturePackages)
+	assert.ErrorContains(t, err, stale+" is out of date")
+	assert.Contains(t, out, "Changed paths:\n  /entities\n")
+	assert.Contains(t, out, "Changed schemas:\n  Inner\n")
+
+	_, err = runGenerate("--check", "-o", filepath.Join(t.TempDir(), "missing.json"), fixturePackages)
+	assert.ErrorContains(t, err, "unable to read existing specification")
+
+	_, err = runGenerate("--check", "-o", "-", fixturePackages)
+	assert.ErrorContains(t, err, "checking the specification requires an output file")
+}
+
+func TestGenerateCheckExitCode(t *testing.T) {
+	if file := os.Getenv("GENERATE_TEST_FILE"); file != "" {
+		os.Args = []string{"openapi", "generate", "--check", "-o", file, fixturePackages}
+		Execute()
+		os.Exit(0)
+	}
+
+	file := filepath.Join(t.TempDir(), "openapi.json")
+	_, err := runGenerate("--check=false", "-o", file, fixturePackages)
+	require.NoError(t, err)
+
+	for file, code := range map[string]int{file: 0, staleDocument(t, file): 1} {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestGenerateCheckExitCode$")
+		cmd.Env = append(os.Environ(), "GENERATE_TEST_FILE="+file)
+		err := cmd.Run()
+
+		exitCode := 0
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		} else {
+			require.NoError(t, err)
+		}
+		assert.Equal(t, code, exitCode)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/neticdk/go-openapi/pkg/generator"
+	"github.com/neticdk/go-openapi/pkg/lint"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	lintConfig    = "lint.config"
+	lintListRules = "lint.listRules"
+
+	defaultLintConfig = ".openapi-lint.yaml"
+)
+
+var (
+	lintCmd = &cobra.Command{
+		Use:   "lint [packages]",
+		Short: "Lint OpenAPI specification generated from source code against API style rules",
+		Long:  "This command will generate the OpenAPI specification from the given packages and check it against the built-in API style rules. The severity of each rule can be configured in a config file and findings can be suppressed using the openapi:nolint directive. The command exits with a non-zero exit code if any findings have severity error.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if viper.GetBool(lintListRules) {
+				for _, r := range lint.Rules {
+					fmt.Fprintf(os.Stdout, "%-25s %-8s %s\n", r.Name, r.Severity, r.Description)
+				}
+				return nil
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("requires at least 1 package")
+			}
+
+			cfg, err := readLintConfig(viper.GetString(lintConfig))
+			if err != nil {
+				return err
+			}
+
+			sources := generator.SourceMap{}
+			spec, err := generateSpec("lint", args, generator.WithSourceMap(sources))
+			if err != nil {
+				return err
+			}
+
+			findings, err := lint.Lint(spec, sources, cfg)
+			if err != nil {
+				return err
+			}
+
+			errorCount := 0
+			for _, f := range findings {
+				fmt.Fprintln(os.Stdout, f)
+				if f.Severity == generator.SeverityError {
+					errorCount++
+				}
+			}
+
+			if errorCount > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d finding(s) of which %d are errors", len(findings), errorCount)
+			}
+			return nil
+		},
+	}
+)
+
+// readLintConfig reads the configuration of the lint rules, the default config file is
+// optional
+func readLintConfig(file string) (lint.Config, error) {
+	cfg := lint.Config{}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		if file == defaultLintConfig && errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("unable to read lint config %s: %w", file, err)
+	}
+	if err := v.Unmarshal(&cfg); err != nil {
+		return cfg, fmt.Errorf("unable to parse lint config %s: %w", file, err)
+	}
+	return cfg, nil
+}
+
+func init() {
+	lintCmd.Flags().StringP("config", "c", defaultLintConfig, "Config file with the severity of the rules")
+	viper.BindPFlag(lintConfig, lintCmd.Flags().Lookup("config"))
+	lintCmd.Flags().Bool("list-rules", false, "List the built-in rules with their default severity")
+	viper.BindPFlag(lintListRules, lintCmd.Flags().Lookup("list-rules"))
+	addGeneratorFlags(lintCmd, "lint")
+
+	rootCmd.AddCommand(lintCmd)
+}
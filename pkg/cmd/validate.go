@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	validateFormat = "validate.format"
+)
+
+type validationReport struct {
+	File     string   `json:"file"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+var (
+	validateCmd = &cobra.Command{
+		Use:   "validate [openapi-file]",
+		Short: "Validate OpenAPI specification document",
+		Long:  "Running this command will validate the OpenAPI specification document against the OpenAPI meta-schema and perform semantic checks such as unique operation ids, resolvable references, consistent path parameters and required response descriptions. The command exits with a non-zero exit code if the document is invalid.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := viper.GetString(validateFormat)
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unsupported output format %s - must be text or json", format)
+			}
+
+			doc, err := loads.Spec(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to load openapi spec: %w", err)
+			}
+
+			validator := validate.NewSpecValidator(doc.Schema(), strfmt.Default)
+			validator.SetContinueOnErrors(true)
+			res, _ := validator.Validate(doc)
+
+			report := validationReport{
+				File:     args[0],
+				Valid:    !res.HasErrors(),
+				Errors:   []string{},
+				Warnings: []string{},
+			}
+			for _, e := range res.Errors {
+				report.Errors = append(report.Errors, e.Error())
+			}
+			for _, w := range res.Warnings {
+				report.Warnings = append(report.Warnings, w.Error())
+			}
+			slices.Sort(report.Errors)
+			slices.Sort(report.Warnings)
+
+			if format == "json" {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return fmt.Errorf("unable to encode validation result: %w", err)
+				}
+			} else {
+				for _, e := range report.Errors {
+					fmt.Fprintf(cmd.OutOrStdout(), "error: %s\n", e)
+				}
+				for _, w := range report.Warnings {
+					fmt.Fprintf(cmd.OutOrStdout(), "warning: %s\n", w)
+				}
+				if report.Valid {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s is valid (%d warning(s))\n", report.File, len(report.Warnings))
+				}
+			}
+
+			if !report.Valid {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%s is invalid: %d error(s), %d warning(s)", report.File, len(report.Errors), len(report.Warnings))
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	validateCmd.Flags().StringP("format", "f", "text", "Output format of the validation result, either text or json")
+	viper.BindPFlag(validateFormat, validateCmd.Flags().Lookup("format"))
+
+	rootCmd.AddCommand(validateCmd)
+}
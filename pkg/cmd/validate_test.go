@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validDocument = `{
+  "swagger": "2.0",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {
+    "/items": {
+      "get": {
+        "operationId": "listItems",
+        "responses": {"200": {"description": "the items"}}
+      }
+    }
+  }
+}`
+
+const invalidDocument = `{
+  "swagger": "2.0",
+  "info": {"title": "Test", "version": "1.0.0"},
+  "paths": {
+    "/items": {
+      "get": {
+        "operationId": "listItems",
+        "responses": {"200": {"description": "the items", "schema": {"$ref": "#/definitions/Item"}}}
+      }
+    }
+  }
+}`
+
+// writeDocument writes the document to a temporary file returning the path of the file
+func writeDocument(t *testing.T, doc string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "openapi.json")
+	require.NoError(t, os.WriteFile(file, []byte(doc), 0o600))
+	return file
+}
+
+// runValidate runs the validate command returning the output and the error of the command
+func runValidate(args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs(append([]string{"validate"}, args...))
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func TestValidate(t *testing.T) {
+	valid := writeDocument(t, validDocument)
+	out, err := runValidate("--format", "text", valid)
+	assert.NoError(t, err)
+	assert.Equal(t, valid+" is valid (0 warning(s))\n", out)
+
+	invalid := writeDocument(t, invalidDocument)
+	out, err = runValidate("--format", "text", invalid)
+	assert.ErrorContains(t, err, invalid+" is invalid")
+	assert.Contains(t, out, "error: ")
+	assert.Contains(t, out, "could not resolve reference")
+	assert.NotContains(t, out, "is valid")
+
+	out, err = runValidate("--format", "json", invalid)
+	assert.Error(t, err)
+	report := validationReport{}
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, invalid, report.File)
+	assert.False(t, report.Valid)
+	assert.NotEmpty(t, report.Errors)
+
+	_, err = runValidate("--format", "yaml", valid)
+	assert.ErrorContains(t, err, "unsupported output format yaml")
+}
+
+func TestValidateExitCode(t *testing.T) {
+	if file := os.Getenv("VALIDATE_TEST_FILE"); file != "" {
+		os.Args = []string{"openapi", "validate", "--format", "text", file}
+		Execute()
+		os.Exit(0)
+	}
+
+	for doc, code := range map[string]int{validDocument: 0, invalidDocument: 1} {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestValidateExitCode$")
+		cmd.Env = append(os.Environ(), "VALIDATE_TEST_FILE="+writeDocument(t, doc))
+		err := cmd.Run()
+
+		exitCode := 0
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		} else {
+			require.NoError(t, err)
+		}
+		assert.Equal(t, code, exitCode)
+	}
+}
@@ -0,0 +1,154 @@
+// Package diff provides semantic comparison of JSON documents such as OpenAPI specifications
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Kind of change between two documents
+type Kind int
+
+const (
+	Added Kind = iota
+	Removed
+	Modified
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// Change is a difference between two documents found at the location given by the path
+type Change struct {
+	Path []string
+	Kind Kind
+	Old  any
+	New  any
+}
+
+// Location returns the path of the change in a readable form, e.g., `paths["/entities"].get`
+func (c Change) Location() string {
+	var sb strings.Builder
+	for i, p := range c.Path {
+		if strings.ContainsAny(p, "./[]\" ") {
+			sb.WriteString("[" + strconv.Quote(p) + "]")
+			continue
+		}
+		if i > 0 {
+			sb.WriteString(".")
+		}
+		sb.WriteString(p)
+	}
+	return sb.String()
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("%s %s: %s", c.Kind, c.Location(), summary(c.New))
+	case Removed:
+		return fmt.Sprintf("%s %s: %s", c.Kind, c.Location(), summary(c.Old))
+	default:
+		return fmt.Sprintf("%s %s: %s -> %s", c.Kind, c.Location(), summary(c.Old), summary(c.New))
+	}
+}
+
+// Documents compares two JSON documents ignoring formatting and ordering of object members
+func Documents(from, to []byte) ([]Change, error) {
+	var o, n any
+	if err := json.Unmarshal(from, &o); err != nil {
+		return nil, fmt.Errorf("unable to parse old document: %w", err)
+	}
+	if err := json.Unmarshal(to, &n); err != nil {
+		return nil, fmt.Errorf("unable to parse new document: %w", err)
+	}
+	return Compare(o, n), nil
+}
+
+// Compare compares two values as decoded by encoding/json into `any` and returns the
+// changes sorted by path
+func Compare(from, to any) []Change {
+	return compare(nil, from, to, []Change{})
+}
+
+func compare(path []string, from, to any, changes []Change) []Change {
+	switch o := from.(type) {
+	case map[string]any:
+		n, ok := to.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := []string{}
+		for k := range o {
+			keys = append(keys, k)
+		}
+		for k := range n {
+			if _, ok := o[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			ov, inOld := o[k]
+			nv, inNew := n[k]
+			p := append(slices.Clip(path), k)
+			switch {
+			case !inOld:
+				changes = append(changes, Change{Path: p, Kind: Added, New: nv})
+			case !inNew:
+				changes = append(changes, Change{Path: p, Kind: Removed, Old: ov})
+			default:
+				changes = compare(p, ov, nv, changes)
+			}
+		}
+		return changes
+
+	case []any:
+		n, ok := to.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < max(len(o), len(n)); i++ {
+			p := append(slices.Clip(path), strconv.Itoa(i))
+			switch {
+			case i >= len(o):
+				changes = append(changes, Change{Path: p, Kind: Added, New: n[i]})
+			case i >= len(n):
+				changes = append(changes, Change{Path: p, Kind: Removed, Old: o[i]})
+			default:
+				changes = compare(p, o[i], n[i], changes)
+			}
+		}
+		return changes
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		changes = append(changes, Change{Path: path, Kind: Modified, Old: from, New: to})
+	}
+	return changes
+}
+
+// summary renders the value as compact JSON shortened to be readable on a single line
+func summary(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	s := string(b)
+	if len(s) > 60 {
+		s = s[:57] + "..."
+	}
+	return s
+}
@@ -0,0 +1,32 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocuments(t *testing.T) {
+	from := []byte(`{
+		"paths": {"/entities/{id}": {"get": {"description": "old"}}},
+		"definitions": {"Model": {"type": "object"}, "Removed": {}},
+		"tags": ["a", "b"]
+	}`)
+	to := []byte(`{"tags":["a"],"definitions":{"Model":{"type":"object"},"Added":{}},"paths":{"/entities/{id}":{"get":{"description":"new"}}}}`)
+
+	changes, err := Documents(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	assert.Equal(t, "+ definitions.Added: {}", changes[0].String())
+	assert.Equal(t, "- definitions.Removed: {}", changes[1].String())
+	assert.Equal(t, `~ paths["/entities/{id}"].get.description: "old" -> "new"`, changes[2].String())
+	assert.Equal(t, `- tags.1: "b"`, changes[3].String())
+
+	changes, err = Documents(from, from)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, err = Documents(from, []byte("{"))
+	assert.Error(t, err)
+}
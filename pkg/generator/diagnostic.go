@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Severity of a diagnostic
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+// Diagnostic is a problem found while generating the specification
+type Diagnostic struct {
+	Pos      token.Position
+	Severity Severity
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	if d.Pos.IsValid() {
+		return fmt.Sprintf("%s: %s: %s", d.Pos, d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+}
+
+// logDiagnostic is the default reporter logging diagnostics
+func logDiagnostic(d Diagnostic) {
+	ev := log.Warn()
+	if d.Severity == SeverityError {
+		ev = log.Error()
+	}
+	if d.Pos.IsValid() {
+		ev = ev.Str("pos", d.Pos.String())
+	}
+	ev.Msg(d.Message)
+}
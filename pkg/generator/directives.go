@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Names of the openapi and schema directives as given by Directive.Name
+const (
+	DirectiveInfo             = "info"
+	DirectiveComponent        = "component"
+	DirectiveOperation        = "operation"
+	DirectiveParameter        = "parameter"
+	DirectiveTag              = "tag"
+	DirectiveSummary          = "summary"
+	DirectiveResponse         = "response"
+	DirectiveResponseContent  = "responseContent"
+	DirectiveResponseHeader   = "responseHeader"
+	DirectiveResponseExample  = "responseExample"
+	DirectiveRequestBody      = "requestBody"
+	DirectiveNolint           = "nolint"
+	DirectiveDiscriminator    = "discriminator"
+	DirectiveOneOf            = "oneOf"
+	DirectiveSchemaType       = "schemaType"
+	DirectiveSchema           = "schema"
+	DirectiveTypeMapping      = "typeMapping"
+	DirectiveExample          = "example"
+	DirectiveFormat           = "format"
+	DirectiveDefault          = "default"
+	DirectiveMinimum          = "minimum"
+	DirectiveMaximum          = "maximum"
+	DirectiveExclusiveMinimum = "exclusiveMinimum"
+	DirectiveExclusiveMaximum = "exclusiveMaximum"
+	DirectiveMultipleOf       = "multipleOf"
+	DirectiveMinLength        = "minLength"
+	DirectiveMaxLength        = "maxLength"
+	DirectivePattern          = "pattern"
+	DirectiveMinItems         = "minItems"
+	DirectiveMaxItems         = "maxItems"
+	DirectiveUniqueItems      = "uniqueItems"
+	DirectiveRequired         = "required"
+	DirectiveOptional         = "optional"
+	DirectiveNullable         = "nullable"
+	DirectiveReadOnly         = "readOnly"
+	DirectiveWriteOnly        = "writeOnly"
+)
+
+// itemsPrefix prefixes schema directives applying to the items of a slice rather than the
+// slice itself, e.g., //schema:items.format uuid
+const itemsPrefix = "items."
+
+var directiveExp = regexp.MustCompile(`^//(openapi|schema):((?:items\.)*)(\w+)`)
+
+// directiveSyntax describes the syntax of a directive. Schema directives may be used with
+// both the openapi: and the schema: prefix. The arguments of the directive are the
+// submatches of the expression given by groups.
+type directiveSyntax struct {
+	name   string
+	usage  string
+	schema bool
+	expr   *regexp.Regexp
+	groups []int
+}
+
+var directiveSyntaxes = []*directiveSyntax{
+	newDirectiveSyntax(DirectiveInfo, "<version>", false, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveComponent, "schema <name>", false, ` (schema) (\w+)`, 1, 2),
+	newDirectiveSyntax(DirectiveOperation, "<path> <http-method>", false, ` (\S+) (get|GET|put|PUT|post|POST|delete|DELETE|options|OPTIONS|head|HEAD|patch|PATCH|trace|TRACE)`, 1, 2),
+	newDirectiveSyntax(DirectiveParameter, `<name> <param-type> <type>[/<format>] ["description"]`, false, ` (\w+) (path|query) (\w+)(/(\S+))?( "([^"]+)")?`, 1, 2, 3, 5, 7),
+	newDirectiveSyntax(DirectiveTag, "<tag>", false, ` (\w+)`, 1),
+	newDirectiveSyntax(DirectiveSummary, `"summary"`, false, ` "([^"]+)"`, 1),
+	newDirectiveSyntax(DirectiveResponse, `<code> ["description"]`, false, ` (default|[0-9]{3})( "([^"]+)")?`, 1, 3),
+	newDirectiveSyntax(DirectiveResponseContent, "<code> <media-type> <model>", false, ` (default|[0-9]{3}) (\S+) (\w+(?:\[\S+\])?)`, 1, 2, 3),
+	newDirectiveSyntax(DirectiveResponseHeader, `<code> <name> <type>[/<format>] ["description"]`, false, ` (default|[0-9]{3}) (\S+) (\w+)(/(\S+))?( "([^"]+)")?`, 1, 2, 3, 5, 7),
+	newDirectiveSyntax(DirectiveResponseExample, "<code> <media-type> <file>", false, ` (default|[0-9]{3}) (\S+) (\S+)`, 1, 2, 3),
+	newDirectiveSyntax(DirectiveRequestBody, `<media-type> <model> [required] ["description"]`, false, ` (\S+) (\w+(?:\[\S+\])?)( (true|false))?( "([^"]+)")?`, 1, 2, 4, 6),
+	newDirectiveSyntax(DirectiveNolint, "[rule...]", false, `( (.+))?`, 2),
+	newDirectiveSyntax(DirectiveDiscriminator, "<property>", false, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveOneOf, "<type>...", false, ` (\w+( \w+)*)`, 1),
+	newDirectiveSyntax(DirectiveSchemaType, "<type>[/<format>]", false, ` (\w+(?:/\S+)?)`, 1),
+	newDirectiveSyntax(DirectiveSchema, "<json-schema>", false, ` (\{.*\})`, 1),
+	newDirectiveSyntax(DirectiveTypeMapping, "<import/path.Type> <type>[/<format>]|<json-schema>", false, ` (\S+\.\w+) (\w+(/\S+)?|\{.*\})`, 1, 2),
+	newDirectiveSyntax(DirectiveExample, "<value>", true, ` (.*)`, 1),
+	newDirectiveSyntax(DirectiveFormat, "<format>", true, ` (.*)`, 1),
+	newDirectiveSyntax(DirectiveDefault, "<value>", true, ` (.*)`, 1),
+	newDirectiveSyntax(DirectiveMinimum, "<number>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveMaximum, "<number>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveExclusiveMinimum, "<number>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveExclusiveMaximum, "<number>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveMultipleOf, "<number>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveMinLength, "<length>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveMaxLength, "<length>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectivePattern, "<regexp>", true, ` (.+)`, 1),
+	newDirectiveSyntax(DirectiveMinItems, "<count>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveMaxItems, "<count>", true, ` (\S+)`, 1),
+	newDirectiveSyntax(DirectiveUniqueItems, "[true|false]", true, `( (true|false))?`, 2),
+	newDirectiveSyntax(DirectiveRequired, "", true, ``),
+	newDirectiveSyntax(DirectiveOptional, "", true, ``),
+	newDirectiveSyntax(DirectiveNullable, "[true|false]", true, `( (true|false))?`, 2),
+	newDirectiveSyntax(DirectiveReadOnly, "[true|false]", true, `( (true|false))?`, 2),
+	newDirectiveSyntax(DirectiveWriteOnly, "[true|false]", true, `( (true|false))?`, 2),
+}
+
+func newDirectiveSyntax(name, usage string, schema bool, args string, groups ...int) *directiveSyntax {
+	prefix := "openapi"
+	if schema {
+		prefix = "(?:openapi|schema)"
+	}
+	items := ""
+	if schema {
+		items = `(?:items\.)*`
+	}
+	return &directiveSyntax{
+		name:   name,
+		usage:  usage,
+		schema: schema,
+		expr:   regexp.MustCompile("^//" + prefix + ":" + items + name + args + "$"),
+		groups: groups,
+	}
+}
+
+// Directive is a godoc directive parsed from a comment
+type Directive struct {
+	Prefix string   // Prefix of the directive, i.e., openapi or schema
+	Name   string   // Name of the directive, e.g., operation
+	Args   []string // Arguments in the order documented by the usage, optional arguments may be empty
+	Items  int      // Number of items. prefixes, i.e., the nesting of the items the directive applies to
+}
+
+// ParseDirective parses a comment line as a directive. The directive is nil if the comment
+// is not a directive, i.e., does not start with //openapi: or //schema:. An error is
+// returned if the directive is unknown or malformed.
+func ParseDirective(comment string) (*Directive, error) {
+	m := directiveExp.FindStringSubmatch(comment)
+	if m == nil {
+		return nil, nil
+	}
+
+	prefix, items, name := m[1], m[2], m[3]
+	i := slices.IndexFunc(directiveSyntaxes, func(s *directiveSyntax) bool { return s.name == name })
+	if i < 0 || (prefix == "schema" && !directiveSyntaxes[i].schema) || (items != "" && !directiveSyntaxes[i].schema) {
+		return nil, fmt.Errorf("unknown directive %s:%s%s", prefix, items, name)
+	}
+
+	syntax := directiveSyntaxes[i]
+	sm := syntax.expr.FindStringSubmatch(strings.TrimRight(comment, " \t"))
+	if sm == nil {
+		return nil, fmt.Errorf("malformed directive %s:%s%s - expected //%s:%s%s %s", prefix, items, name, prefix, items, name, syntax.usage)
+	}
+
+	d := &Directive{Prefix: prefix, Name: name, Items: strings.Count(items, itemsPrefix)}
+	for _, g := range syntax.groups {
+		d.Args = append(d.Args, sm[g])
+	}
+	return d, nil
+}
+
+// DirectiveNames returns the names of the known directives
+func DirectiveNames() []string {
+	names := []string{}
+	for _, s := range directiveSyntaxes {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// directives returns the valid directives with the given name from the comment group
+func directives(doc *ast.CommentGroup, name string) []*Directive {
+	ds := []*Directive{}
+	if doc == nil {
+		return ds
+	}
+	for _, c := range doc.List {
+		if d, err := ParseDirective(c.Text); err == nil && d != nil && d.Name == name {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+// validationErrorExp splits a validation error into the dotted path of the offending value
+// and the reason
+var validationErrorExp = regexp.MustCompile(`^\.?(\S*) in body (.*)$`)
+
+// validateExamples validates the examples of properties and responses of the specification
+// against their schemas. Schemas are validated strictly, i.e., properties not part of the
+// schema are reported unless the schema explicitly allows additional properties.
+func validateExamples(doc *spec.Swagger, cfg *config) {
+	var responseRoot *spec.Swagger
+	root, err := strictCopy(doc, false)
+	if err == nil {
+		responseRoot, err = strictCopy(doc, true)
+	}
+	if err != nil {
+		cfg.report(Diagnostic{Severity: SeverityWarning, Message: fmt.Sprintf("unable to validate examples: %s", err)})
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(root.Definitions)) {
+		schema := root.Definitions[name]
+		walkSchema(Pointer("definitions", name), &schema, func(ptr string, s *spec.Schema) {
+			if s.Example == nil {
+				return
+			}
+			for _, e := range validateExample(root, s, s.Example) {
+				cfg.report(Diagnostic{
+					Pos:      cfg.sources.Position(ptr),
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("example of %s does not match the schema: %s", ptr, e),
+				})
+			}
+		})
+	}
+
+	Operations(root, func(path, method string, op *spec.Operation) {
+		if op.Responses == nil {
+			return
+		}
+		responses := map[string]spec.Response{}
+		if op.Responses.Default != nil {
+			responses["default"] = *op.Responses.Default
+		}
+		for code, r := range op.Responses.StatusCodeResponses {
+			responses[strconv.Itoa(code)] = r
+		}
+		for _, code := range slices.Sorted(maps.Keys(responses)) {
+			r := responses[code]
+			if r.Schema == nil {
+				continue
+			}
+			for _, mediaType := range slices.Sorted(maps.Keys(r.Examples)) {
+				if !strings.Contains(mediaType, "json") {
+					continue
+				}
+				ptr := Pointer("paths", path, method, "responses", code, "examples", mediaType)
+				for _, e := range validateExample(responseRoot, r.Schema, r.Examples[mediaType]) {
+					cfg.report(Diagnostic{
+						Pos:      cfg.sources.Position(ptr),
+						Severity: SeverityWarning,
+						Message:  fmt.Sprintf("example for response %s of %s %s does not match the schema: %s", code, strings.ToUpper(method), path, e),
+					})
+				}
+			}
+		}
+	})
+}
+
+// validateExample validates the example against the schema returning the errors found
+// prefixed by the JSON pointer to the offending value within the example
+func validateExample(root *spec.Swagger, schema *spec.Schema, example any) []string {
+	res := validate.NewSchemaValidator(schema, root, "", strfmt.Default).Validate(example)
+	if res == nil {
+		return nil
+	}
+	msgs := []string{}
+	var collect func(errs []error)
+	collect = func(errs []error) {
+		for _, err := range errs {
+			switch e := err.(type) {
+			case *errors.CompositeError:
+				collect(e.Errors)
+			case *errors.Validation:
+				if m := validationErrorExp.FindStringSubmatch(e.Error()); m != nil {
+					msgs = append(msgs, dottedPointer(m[1])+" "+m[2])
+				} else {
+					msgs = append(msgs, e.Error())
+				}
+			case errors.Error:
+				if e.Code() == errors.CompositeErrorCode {
+					continue // Summary of the errors of allOf, anyOf etc. reported on their own
+				}
+				msgs = append(msgs, e.Error())
+			default:
+				msgs = append(msgs, err.Error())
+			}
+		}
+	}
+	collect(res.Errors)
+	slices.Sort(msgs)
+	return slices.Compact(msgs)
+}
+
+// dottedPointer converts the dotted path used by validation errors to a JSON pointer
+func dottedPointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return Pointer(strings.Split(path, ".")...)
+}
+
+// strictCopy returns a copy of the specification where object schemas with properties do not
+// allow additional properties unless explicitly allowed by the schema. Schemas marked by
+// x-nullable are made nullable for the validator. The copy used for responses leaves out
+// writeOnly properties such that they are neither required nor allowed in responses.
+func strictCopy(doc *spec.Swagger, response bool) (*spec.Swagger, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	root := &spec.Swagger{}
+	if err := json.Unmarshal(b, root); err != nil {
+		return nil, err
+	}
+
+	strict := func(ptr string, s *spec.Schema) {
+		if response {
+			for name, prop := range s.Properties {
+				if writeOnly, _ := prop.Extensions.GetBool(extWriteOnly); writeOnly {
+					delete(s.Properties, name)
+					s.Required = slices.DeleteFunc(s.Required, func(r string) bool { return r == name })
+				}
+			}
+		}
+		// Members of allOf and polymorphic base schemas are only part of the full schema
+		polymorphic := s.Discriminator != "" || strings.HasSuffix(path.Dir(ptr), "/allOf")
+		if len(s.Properties) > 0 && s.AdditionalProperties == nil && !polymorphic {
+			s.AdditionalProperties = &spec.SchemaOrBool{Allows: false}
+		}
+		if nullable, ok := s.Extensions.GetBool(extNullable); ok && nullable {
+			s.Nullable = true
+		}
+	}
+	for name, schema := range root.Definitions {
+		walkSchema("", &schema, strict)
+		root.Definitions[name] = schema
+	}
+	return root, nil
+}
+
+// walkSchema calls the function for the schema and all inline schemas nested within it
+func walkSchema(ptr string, schema *spec.Schema, fn func(ptr string, s *spec.Schema)) {
+	fn(ptr, schema)
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		prop := schema.Properties[name]
+		walkSchema(ptr+Pointer("properties", name), &prop, fn)
+		schema.Properties[name] = prop
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		walkSchema(ptr+Pointer("items"), schema.Items.Schema, fn)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		walkSchema(ptr+Pointer("additionalProperties"), schema.AdditionalProperties.Schema, fn)
+	}
+	for i := range schema.AllOf {
+		walkSchema(ptr+Pointer("allOf", strconv.Itoa(i)), &schema.AllOf[i], fn)
+	}
+}
@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"cmp"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// jsonField is a struct field as seen by encoding/json, i.e., possibly promoted from
+// an embedded struct
+type jsonField struct {
+	v       *types.Var
+	name    string
+	tagged  bool   // name was given by json tag
+	options string // json tag options following the name
+	tag     string // complete struct tag
+	index   []int
+	typ     types.Type // type of field with unnamed pointer dereferenced
+	pointer bool       // field is promoted through an embedded pointer
+}
+
+// jsonFields returns the fields of the struct which encoding/json would serialize. The
+// rules for promotion and shadowing of embedded fields follows the rules of typeFields
+// from encoding/json.
+func jsonFields(st types.Type) []jsonField {
+	current := []jsonField{}
+	next := []jsonField{{typ: st}}
+
+	var count, nextCount typeutil.Map
+	visited := typeutil.Map{}
+
+	fields := []jsonField{}
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, typeutil.Map{}
+
+		for _, f := range current {
+			if visited.At(f.typ) != nil {
+				continue
+			}
+			visited.Set(f.typ, true)
+
+			s, ok := f.typ.Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+			for i := 0; i < s.NumFields(); i++ {
+				sf := s.Field(i)
+				if sf.Embedded() {
+					t := types.Unalias(sf.Type())
+					if p, ok := t.(*types.Pointer); ok {
+						t = p.Elem()
+					}
+					if _, ok := t.Underlying().(*types.Struct); !sf.Exported() && !ok {
+						// Ignore embedded fields of unexported non-struct types
+						continue
+					}
+					// Do not ignore embedded fields of unexported struct types since they may
+					// have exported fields
+				} else if !sf.Exported() {
+					continue
+				}
+
+				tag := reflect.StructTag(s.Tag(i)).Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, options, _ := strings.Cut(tag, ",")
+				if !isValidTag(name) {
+					name = ""
+				}
+				index := make([]int, len(f.index)+1)
+				copy(index, f.index)
+				index[len(f.index)] = i
+
+				ft := types.Unalias(sf.Type())
+				if p, ok := ft.(*types.Pointer); ok {
+					ft = types.Unalias(p.Elem())
+				}
+
+				// Record found field and index sequence, structs inlined by the option inline of
+				// encoding/json v2 are explored like embedded structs
+				_, isStruct := ft.Underlying().(*types.Struct)
+				inline := isStruct && hasOption(options, "inline")
+				if (name != "" || !sf.Embedded() || !isStruct) && !inline {
+					tagged := name != ""
+					if name == "" {
+						name = sf.Name()
+					}
+					fields = append(fields, jsonField{
+						v:       sf,
+						name:    name,
+						tagged:  tagged,
+						options: options,
+						tag:     s.Tag(i),
+						index:   index,
+						typ:     ft,
+						pointer: f.pointer,
+					})
+					if c, _ := count.At(f.typ).(int); c > 1 {
+						// If there were multiple instances, add a second, so that the annihilation
+						// code will see a duplicate
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+
+				// Record new anonymous struct to explore in next round
+				c, _ := nextCount.At(ft).(int)
+				nextCount.Set(ft, c+1)
+				if c == 0 {
+					_, isPointer := types.Unalias(sf.Type()).(*types.Pointer)
+					next = append(next, jsonField{name: sf.Name(), index: index, typ: ft, pointer: f.pointer || isPointer})
+				}
+			}
+		}
+	}
+
+	// Sort field by name, breaking ties with depth, then breaking ties with "name came
+	// from json tag", then breaking ties with index sequence
+	slices.SortFunc(fields, func(a, b jsonField) int {
+		if c := strings.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(a.index), len(b.index)); c != 0 {
+			return c
+		}
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+			return +1
+		}
+		return slices.Compare(a.index, b.index)
+	})
+
+	// Delete all fields that are hidden by the Go rules for embedded fields, except that
+	// fields with JSON tags are promoted
+	out := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		fi := fields[i]
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != fi.name {
+				break
+			}
+		}
+		if advance == 1 {
+			out = append(out, fi)
+			continue
+		}
+		if dominant, ok := dominantField(fields[i : i+advance]); ok {
+			out = append(out, dominant)
+		}
+	}
+
+	fields = out
+	slices.SortFunc(fields, func(a, b jsonField) int { return slices.Compare(a.index, b.index) })
+	return fields
+}
+
+// dominantField looks through the fields, all of which are known to have the same name,
+// to find the single field that dominates the others using Go's embedding rules,
+// modified by the presence of JSON tags. If there are multiple top-level fields, the
+// boolean will be false: This condition is an error in Go and we skip all the fields.
+func dominantField(fields []jsonField) (jsonField, bool) {
+	// The fields are sorted in increasing index-length order, then by presence of tag.
+	// That means that the first field is the dominant one. We need only check for error
+	// cases: two fields at top level, either both tagged or neither tagged.
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return jsonField{}, false
+	}
+	return fields[0], true
+}
+
+// isValidTag is identical to the check in encoding/json
+func isValidTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// Backslash and quote chars are reserved, but otherwise any punctuation chars
+			// are allowed in a tag name.
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
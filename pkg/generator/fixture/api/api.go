@@ -3,13 +3,17 @@ package api
 // ListOperation lists the entities
 //
 //openapi:operation /entities GET
+//openapi:summary "List entities"
+//openapi:parameter status query Status "filter entities by status"
 //openapi:tag tag1
 //openapi:tag tag2
+//openapi:nolint list-pagination
 func ListOperation() {}
 
 // GetOperation gets a specific entity
 //
 //openapi:operation /entities/{id} GET
+//openapi:summary "Get an entity"
 //openapi:parameter id path string "the id of the entity"
 //openapi:response default "this is a description"
 //openapi:responseContent default application/json Model
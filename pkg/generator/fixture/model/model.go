@@ -26,8 +26,21 @@ type Model struct {
 	Field6 RefExported       `json:"field6"`
 	Field7 []string          `json:"field7"`
 	Field8 map[string]string `json:"field8"`
+
+	// Status of the model
+	Status Status `json:"status"`
 }
 
+// Status is an enumeration of the states of a model
+type Status string
+
+const (
+	// StatusActive is the state of models in use
+	StatusActive  Status = "active"
+	StatusRetired Status = "retired" // StatusRetired is the state of models no longer in use
+	statusUnknown Status = "unknown"
+)
+
 // embeddedPrivate is a struct which will be embedded but not exported
 type embeddedPrivate struct {
 	EmbeddedField string `json:"eField"`
@@ -53,6 +66,7 @@ type Problem struct {
 	Type string `json:"type,omitempty"`
 
 	// Status is the http status code and must be consistent with the server status code RFC-9457#3.1.2
+	//schema:example 404
 	Status *int `json:"status,omitempty"`
 
 	// Title is short humanreadable summary RFC-9457#3.1.3
@@ -67,3 +81,86 @@ type Problem struct {
 	// Err is containing wrapped error and will not be serialized to JSON
 	Err error `json:"-"`
 }
+
+// Node is a recursive structure referencing itself
+//
+//openapi:component schema Node
+type Node struct {
+	Name     string `json:"name"`
+	Parent   *Node  `json:"parent,omitempty"`
+	Children []Node `json:"children"`
+	Folder   Folder `json:"folder"`
+}
+
+// Folder is mutually recursive with File
+type Folder struct {
+	Files []File `json:"files"`
+}
+
+// File is mutually recursive with Folder
+type File struct {
+	Parent *Folder `json:"parent"`
+}
+
+// Embedding illustrates promotion and shadowing of embedded fields following encoding/json
+//
+//openapi:component schema Embedding
+type Embedding struct {
+	// A and B are declared together and share documentation
+	A, B string
+
+	*Promoted
+	Named     `json:"named"`
+	conflictA // Conflict is defined at the same depth in both
+	conflictB // conflictA and conflictB and is therefore dropped
+	taggedA   // Tagged is dominated by the json tag in taggedB
+	taggedB
+
+	// Shadow shadows Promoted.Shadow
+	Shadow string `json:"shadow"`
+}
+
+// Promoted is embedded as a pointer
+type Promoted struct {
+	// Promoted is promoted from embedded pointer
+	Promoted string `json:"promoted"`
+	Shadow   int    `json:"shadow"`
+}
+
+// Named is embedded with a json name and will become a property
+type Named struct {
+	Value string `json:"value"`
+}
+
+type conflictA struct {
+	Conflict string
+}
+
+type conflictB struct {
+	Conflict string
+}
+
+type taggedA struct {
+	Tagged string
+}
+
+type taggedB struct {
+	Other string `json:"Tagged"`
+}
+
+// Inner is referenced through an alias
+type Inner struct {
+	Value string `json:"value"`
+}
+
+// Alias is an alias of Inner
+type Alias = Inner
+
+// Aliases has fields of alias types, including any which is an alias of interface{}
+//
+//openapi:component schema Aliases
+type Aliases struct {
+	Any     any    `json:"any"`
+	Alias   Alias  `json:"alias"`
+	Pointer *Alias `json:"pointer,omitempty"`
+}
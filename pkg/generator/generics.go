@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// nonWordExp matches the characters of a type which cannot be part of a component name
+var nonWordExp = regexp.MustCompile(`\W+`)
+
+// typeName returns the component name of the named type. Instantiations of generic types are
+// named by the component name of the generic type followed by the names of the type arguments,
+// e.g., Page[Model] is named PageModel or Page_Model using the separator `_`. Pointer type arguments
+// are suffixed by Ptr such that Page[*Model] is named PageModelPtr.
+func (sg *schemaGenerator) typeName(named *types.Named) string {
+	name := sg.componentName(named.Obj())
+	args := named.TypeArgs()
+	for i := 0; i < args.Len(); i++ {
+		name += sg.cfg.genericSeparator + sg.argName(args.At(i))
+	}
+	return name
+}
+
+// argName returns the name of a type argument as part of the component name of an
+// instantiation of a generic type
+func (sg *schemaGenerator) argName(t types.Type) string {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		return sg.typeName(t)
+	case *types.Basic:
+		return capitalize(t.Name())
+	case *types.Pointer:
+		return sg.argName(t.Elem()) + "Ptr"
+	case *types.Slice:
+		return sg.argName(t.Elem()) + "List"
+	case *types.Array:
+		return sg.argName(t.Elem()) + "List"
+	case *types.Map:
+		return sg.argName(t.Key()) + sg.argName(t.Elem()) + "Map"
+	case *types.Interface:
+		if t.Empty() {
+			return "Any"
+		}
+	}
+	return capitalize(nonWordExp.ReplaceAllString(types.TypeString(t, func(p *types.Package) string { return "" }), ""))
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// operationInstances generates the instantiations of generic types referenced by the
+// openapi:responseContent and openapi:requestBody directives of the operations of the
+// package. The type arguments are resolved as Go types in the scope of the package.
+func (sg *schemaGenerator) operationInstances(p *packages.Package) {
+	if p.Types == nil {
+		return
+	}
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || fd.Doc == nil {
+				continue
+			}
+			for _, c := range fd.Doc.List {
+				d, err := ParseDirective(c.Text)
+				if err != nil || d == nil {
+					continue
+				}
+				var expr string
+				switch d.Name {
+				case DirectiveResponseContent:
+					expr = d.Args[2]
+				case DirectiveRequestBody:
+					expr = d.Args[1]
+				}
+				if !strings.Contains(expr, "[") {
+					continue
+				}
+
+				tv, err := types.Eval(p.Fset, p.Types, c.Pos(), expr)
+				if err != nil {
+					sg.report(c.Pos(), SeverityError, "type %s cannot be resolved: %s", expr, err)
+					continue
+				}
+				named, ok := types.Unalias(tv.Type).(*types.Named)
+				if !tv.IsType() || !ok {
+					sg.report(c.Pos(), SeverityError, "%s is not a defined type", expr)
+					continue
+				}
+				sg.ref(named)
+				if sg.cfg.instances != nil {
+					sg.cfg.instances[instanceKey{pkg: p.PkgPath, expr: expr}] = sg.typeName(named)
+				}
+			}
+		}
+	}
+}
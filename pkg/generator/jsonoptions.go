@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"encoding/json"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// jsonFormats maps the format option of encoding/json v2 for a type, identified by package path
+// and type name or by kind for byte slices, to the schema of the serialized value - the numeric
+// formats are encoded with a fraction below the unit except for nanoseconds
+var jsonFormats = map[string]map[string]func() *spec.Schema{
+	"time.Time": {
+		"RFC3339":     spec.DateTimeProperty,
+		"RFC3339Nano": spec.DateTimeProperty,
+		"DateOnly":    spec.DateProperty,
+		"unix":        spec.Float64Property,
+		"unixmilli":   spec.Float64Property,
+		"unixmicro":   spec.Float64Property,
+		"unixnano":    spec.Int64Property,
+	},
+	"time.Duration": {
+		"units": spec.StringProperty,
+		"sec":   spec.Float64Property,
+		"milli": spec.Float64Property,
+		"micro": spec.Float64Property,
+		"nano":  spec.Int64Property,
+	},
+	"[]byte": {
+		"base64":    stringFormat("byte"),
+		"base64url": spec.StringProperty,
+		"base32":    spec.StringProperty,
+		"base32hex": spec.StringProperty,
+		"base16":    spec.StringProperty,
+		"array":     func() *spec.Schema { return spec.ArrayProperty(unsigned(spec.Int16Property)()) },
+	},
+}
+
+// hasOption returns true if the json tag options contain the option
+func hasOption(options, option string) bool {
+	return slices.Contains(strings.Split(options, ","), option)
+}
+
+// optionValue returns the value of an option of the form name:value of the json tag options
+func optionValue(options, name string) (string, bool) {
+	for _, o := range strings.Split(options, ",") {
+		if value, ok := strings.CutPrefix(o, name+":"); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// unknown returns true if the field collects the unknown members of the object, i.e., has the
+// option unknown of encoding/json v2 or is a map with the option inline
+func unknown(f jsonField) bool {
+	_, isMap := f.typ.Underlying().(*types.Map)
+	return hasOption(f.options, "unknown") || (isMap && hasOption(f.options, "inline"))
+}
+
+// unknownMembers returns the additional properties of the object given by the field
+// collecting unknown members
+func (sg *schemaGenerator) unknownMembers(f jsonField, ptr string) *spec.SchemaOrBool {
+	if m, ok := f.typ.Underlying().(*types.Map); ok {
+		if elem := sg.handleField(m.Elem(), ptr+Pointer("additionalProperties"), nil); elem != nil {
+			return &spec.SchemaOrBool{Allows: true, Schema: elem}
+		}
+	}
+	return &spec.SchemaOrBool{Allows: true}
+}
+
+// jsonOptions adjusts the schema of the field to the json tag options changing the serialized
+// form, i.e., string quoting numbers and booleans and format of encoding/json v2
+func (sg *schemaGenerator) jsonOptions(prop *spec.Schema, f jsonField) *spec.Schema {
+	if format, ok := optionValue(f.options, "format"); ok {
+		key := f.typ.String()
+		if s, ok := f.typ.Underlying().(*types.Slice); ok && isBytes(s) {
+			key = "[]byte"
+		}
+		fn, ok := jsonFormats[key][format]
+		if !ok {
+			sg.report(f.v.Pos(), SeverityWarning, "json format %s of property %s is not known for the type %s and is ignored", format, f.name, f.typ)
+			return prop
+		}
+		formatted := fn()
+		formatted.Description, formatted.ReadOnly, formatted.Extensions = prop.Description, prop.ReadOnly, prop.Extensions
+		return formatted
+	}
+
+	if b, ok := f.typ.Underlying().(*types.Basic); ok && hasOption(f.options, "string") && b.Info()&(types.IsBoolean|types.IsNumeric) != 0 {
+		return sg.quoted(prop)
+	}
+	return prop
+}
+
+// quoted returns the schema of a number or boolean serialized as a string by the json tag
+// option string. References are inlined as the referenced schema is not quoted.
+func (sg *schemaGenerator) quoted(prop *spec.Schema) *spec.Schema {
+	quoted := spec.StringProperty()
+	quoted.Description, quoted.ReadOnly, quoted.Extensions = prop.Description, prop.ReadOnly, prop.Extensions
+	quoted.Example = quote(prop.Example)
+	quoted.Default = quote(prop.Default)
+
+	enum := prop.Enum
+	if name, ok := strings.CutPrefix(prop.Ref.String(), "#"+refPrefix+"/"); ok && sg.schemas[name] != nil {
+		enum = sg.schemas[name].Enum
+		if quoted.Description == "" {
+			quoted.Description = sg.schemas[name].Description
+		}
+	}
+	for _, v := range enum {
+		quoted.Enum = append(quoted.Enum, quote(v))
+	}
+	return quoted
+}
+
+// quote returns the JSON value as a string as quoted by the json tag option string
+func quote(v any) any {
+	if v == nil {
+		return nil
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	return string(b)
+}
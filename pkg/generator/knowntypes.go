@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"go/types"
+
+	"github.com/go-openapi/spec"
+)
+
+// knownTypes maps well-known types of the standard library and common modules, identified by
+// package path and type name, to the schema of their JSON serialization. The exception is url.URL
+// which is rendered as a uri by convention although encoding/json serializes it as an object.
+var knownTypes = map[string]func() *spec.Schema{
+	"time.Time":                                 spec.DateTimeProperty,
+	"time.Duration":                             spec.Int64Property, // Serialized as nanoseconds
+	"encoding/json.RawMessage":                  func() *spec.Schema { return &spec.Schema{} },
+	"encoding/json/jsontext.Value":              func() *spec.Schema { return &spec.Schema{} },
+	"net/url.URL":                               stringFormat("uri"), // By convention, see above
+	"net.IP":                                    spec.StringProperty, // Either ipv4 or ipv6
+	"net/netip.Addr":                            spec.StringProperty,
+	"net/netip.AddrPort":                        spec.StringProperty,
+	"net/netip.Prefix":                          spec.StringProperty,
+	"math/big.Int":                              jsonType("integer"),
+	"github.com/google/uuid.UUID":               stringFormat("uuid"),
+	"github.com/gofrs/uuid.UUID":                stringFormat("uuid"),
+	"github.com/gofrs/uuid/v5.UUID":             stringFormat("uuid"),
+	"cloud.google.com/go/civil.Date":            spec.DateProperty,
+	"github.com/shopspring/decimal.Decimal":     stringFormat("decimal"),
+	"github.com/shopspring/decimal.NullDecimal": nullableSchema(stringFormat("decimal")),
+}
+
+// checkKnownTypes returns the schema of a well-known type or nil if the type is not known
+func checkKnownTypes(t *types.TypeName) *spec.Schema {
+	if t.Pkg() == nil {
+		return nil
+	}
+	if fn, ok := knownTypes[t.Pkg().Path()+"."+t.Name()]; ok {
+		return fn()
+	}
+	return nil
+}
+
+func jsonType(typ string) func() *spec.Schema {
+	return func() *spec.Schema { return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{typ}}} }
+}
+
+func stringFormat(format string) func() *spec.Schema {
+	return func() *spec.Schema { return spec.StrFmtProperty(format) }
+}
+
+func nullableSchema(fn func() *spec.Schema) func() *spec.Schema {
+	return func() *spec.Schema {
+		s := fn()
+		s.AddExtension(extNullable, true)
+		return s
+	}
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// mapKeyError returns an error if the type contains a map, other than through a defined type,
+// with keys which encoding/json cannot serialize, i.e., keys which are neither strings,
+// integers nor implement encoding.TextMarshaler
+func mapKeyError(t types.Type) error {
+	switch t := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return mapKeyError(t.Elem())
+	case *types.Slice:
+		return mapKeyError(t.Elem())
+	case *types.Array:
+		return mapKeyError(t.Elem())
+	case *types.Map:
+		if !serializableKey(t.Key()) {
+			return fmt.Errorf("map keys of type %s cannot be serialized by encoding/json", t.Key())
+		}
+		return mapKeyError(t.Elem())
+	}
+	return nil
+}
+
+// serializableKey returns true if encoding/json can serialize map keys of the type. Keys are
+// not addressable thus only methods of the value receiver are considered.
+func serializableKey(key types.Type) bool {
+	if b, ok := key.Underlying().(*types.Basic); ok && b.Info()&(types.IsString|types.IsInteger) != 0 {
+		return true
+	}
+	return marshalMethod(key, "MarshalText", false)
+}
+
+// keySchema returns the schema of the keys of a map as serialized by encoding/json or nil if
+// the keys are plain strings. Constraints of referenced schemas, e.g., the enum of the key
+// type, are inlined as the schema describes the property names of the object.
+func (sg *schemaGenerator) keySchema(key types.Type, ptr string) *spec.Schema {
+	if b, ok := types.Unalias(key).(*types.Basic); ok && b.Kind() == types.String {
+		return nil
+	}
+
+	schema := sg.handleField(key, ptr+Pointer(extKeyType), nil)
+	if schema == nil {
+		return nil
+	}
+	if name, ok := strings.CutPrefix(schema.Ref.String(), "#"+refPrefix+"/"); ok && sg.schemas[name] != nil {
+		schema = sg.schemas[name]
+	}
+
+	return &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:      schema.Type,
+			Format:    schema.Format,
+			Enum:      schema.Enum,
+			Pattern:   schema.Pattern,
+			Minimum:   schema.Minimum,
+			Maximum:   schema.Maximum,
+			MinLength: schema.MinLength,
+			MaxLength: schema.MaxLength,
+		},
+	}
+}
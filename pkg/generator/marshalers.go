@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// override returns the schema given explicitly for the type by openapi:schemaType or
+// openapi:schema, the schema is nil if not given or malformed
+func (sg *schemaGenerator) override(named *types.Named, doc *ast.CommentGroup) *spec.Schema {
+	var schema *spec.Schema
+	for _, d := range append(directives(doc, DirectiveSchemaType), directives(doc, DirectiveSchema)...) {
+		s, err := ParseTypeMapping(d.Args[0])
+		if err != nil {
+			sg.report(named.Obj().Pos(), SeverityError, "%s:%s of %s is ignored: %s", d.Prefix, d.Name, named.Obj().Name(), err)
+			continue
+		}
+		schema = s
+	}
+	if schema != nil && schema.Description == "" && doc != nil {
+		schema.WithDescription(strings.TrimSpace(doc.Text()))
+	}
+	return schema
+}
+
+// marshals returns true if the type or a pointer to the type has the marshal method with the
+// given name, i.e., MarshalJSON of json.Marshaler or MarshalText of encoding.TextMarshaler
+func marshals(t types.Type, method string) bool {
+	return marshalMethod(t, method, true)
+}
+
+// marshalMethod returns true if the type has the marshal method with the given name. Methods
+// with pointer receivers are included if values of the type are addressable.
+func marshalMethod(t types.Type, method string, addressable bool) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, addressable, nil, method)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 2 {
+		return false
+	}
+	bytes, ok := sig.Results().At(0).Type().(*types.Slice)
+	if !ok || !types.Identical(bytes.Elem(), types.Typ[types.Byte]) {
+		return false
+	}
+	return types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
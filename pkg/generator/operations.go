@@ -4,10 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -22,9 +22,8 @@ const (
 	paramaterPath  = "path"
 )
 
-var (
-	openapiOperationExp = regexp.MustCompile(`^//openapi:operation (\S+) (get|GET|put|PUT|post|POST|delete|DELETE|options|OPTIONS|head|HEAD|patch|PATCH|trace|TRACE)$`)
-)
+// parameterTypes are the primitive types of non-body parameters
+var parameterTypes = []string{"string", "number", "integer", "boolean", "array", "file"}
 
 var opTypes = map[string]func(*spec.PathItem, *spec.Operation){
 	http.MethodGet:     func(pi *spec.PathItem, op *spec.Operation) { pi.Get = op },
@@ -36,46 +35,31 @@ var opTypes = map[string]func(*spec.PathItem, *spec.Operation){
 	http.MethodPatch:   func(pi *spec.PathItem, op *spec.Operation) { pi.Patch = op },
 }
 
-var opDirectives = []*struct {
-	expr *regexp.Regexp
-	fn   func(*spec.Operation, string, []string)
-}{
-	{
-		expr: regexp.MustCompile(`^//openapi:parameter (\w+) (path|query) (\w+)(/(\S+))?( "([^"]+)")?$`),
-		fn:   func(op *spec.Operation, _ string, m []string) { handleParameter(op, m[1], m[2], m[3], m[5], m[7]) },
-	},
-	{
-		expr: regexp.MustCompile(`^//openapi:tag (\w+)$`),
-		fn:   func(op *spec.Operation, _ string, m []string) { op.WithTags(m[1]) },
-	},
-	{
-		expr: regexp.MustCompile(`^//openapi:response (default|[0-9]{3})( "([^"]+)")?$`),
-		fn:   func(op *spec.Operation, _ string, m []string) { handleResponseDescription(op, m[1], m[3]) },
+var opDirectives = map[string]func(*spec.Operation, string, []string){
+	DirectiveParameter: func(op *spec.Operation, _ string, a []string) { handleParameter(op, a[0], a[1], a[2], a[3], a[4]) },
+	DirectiveTag:       func(op *spec.Operation, _ string, a []string) { op.WithTags(a[0]) },
+	DirectiveSummary:   func(op *spec.Operation, _ string, a []string) { op.WithSummary(a[0]) },
+	DirectiveResponse:  func(op *spec.Operation, _ string, a []string) { handleResponseDescription(op, a[0], a[1]) },
+	DirectiveResponseContent: func(op *spec.Operation, _ string, a []string) {
+		handleResponseContent(op, a[0], a[1], a[2])
 	},
-	{
-		expr: regexp.MustCompile(`^//openapi:responseContent (default|[0-9]{3}) (\S+) (\w+)$`),
-		fn:   func(op *spec.Operation, _ string, m []string) { handleResponseContent(op, m[1], m[2], m[3]) },
+	DirectiveResponseHeader: func(op *spec.Operation, _ string, a []string) {
+		handleResponseHeader(op, a[0], a[1], a[2], a[3], a[4])
 	},
-	{
-		expr: regexp.MustCompile(`^//openapi:responseHeader (default|[0-9]{3}) (\S+) (\w+)(/(\S+))?( "([^"]+)")?$`),
-		fn:   func(op *spec.Operation, _ string, m []string) { handleResponseHeader(op, m[1], m[2], m[3], m[5], m[7]) },
-	},
-	{
-		expr: regexp.MustCompile(`^//openapi:responseExample (default|[0-9]{3}) (\S+) (\S+)$`),
-		fn:   func(op *spec.Operation, file string, m []string) { handleResponseExample(op, file, m[1], m[2], m[3]) },
-	},
-	{
-		expr: regexp.MustCompile(`^//openapi:requestBody (\S+) (\w+)( (true|false))?( "([^"]+)")?$`),
-		fn:   func(op *spec.Operation, _ string, m []string) { handleRequestBody(op, m[1], m[2], m[4], m[6]) },
+	DirectiveResponseExample: func(op *spec.Operation, file string, a []string) {
+		handleResponseExample(op, file, a[0], a[1], a[2])
 	},
+	DirectiveRequestBody: func(op *spec.Operation, _ string, a []string) { handleRequestBody(op, a[0], a[1], a[2], a[3]) },
 }
 
 type operationGenerator struct {
+	cfg   *config
 	paths *spec.Paths
 }
 
-func GenerateOperations(pkgs []*packages.Package) *spec.Paths {
+func GenerateOperations(pkgs []*packages.Package, opts ...Option) *spec.Paths {
 	og := &operationGenerator{
+		cfg: newConfig(opts),
 		paths: &spec.Paths{
 			Paths: map[string]spec.PathItem{},
 		},
@@ -93,14 +77,10 @@ func (og *operationGenerator) Generate(pkgs []*packages.Package) *spec.Paths {
 					continue
 				}
 
-				if fd.Doc != nil {
-					for _, l := range fd.Doc.List {
-						operationMatch := openapiOperationExp.FindStringSubmatch(l.Text)
-						if operationMatch != nil {
-							path, method := operationMatch[1], operationMatch[2]
-							og.operation(fd.Name.String(), file, path, method, fd.Doc)
-						}
-					}
+				for _, d := range directives(fd.Doc, DirectiveOperation) {
+					path, method := d.Args[0], d.Args[1]
+					og.cfg.sources.add(Pointer("paths", path, strings.ToLower(method)), p.Fset.Position(fd.Pos()), fd.Doc)
+					og.operation(p.Fset, p.PkgPath, fd.Name.String(), file, path, method, fd.Doc)
 				}
 			}
 		}
@@ -108,15 +88,34 @@ func (og *operationGenerator) Generate(pkgs []*packages.Package) *spec.Paths {
 	return og.paths
 }
 
-func (og *operationGenerator) operation(id, file, path, method string, doc *ast.CommentGroup) {
+func (og *operationGenerator) operation(fset *token.FileSet, pkg, id, file, path, method string, doc *ast.CommentGroup) {
 	op := spec.NewOperation(id).WithDescription(doc.Text())
 
 	for _, l := range doc.List {
-		for _, dh := range opDirectives {
-			m := dh.expr.FindStringSubmatch(l.Text)
-			if m != nil {
-				dh.fn(op, file, m)
-			}
+		d, err := ParseDirective(l.Text)
+		if err != nil {
+			og.cfg.report(Diagnostic{Pos: fset.Position(l.Pos()), Severity: SeverityError, Message: err.Error()})
+			continue
+		}
+		if d == nil {
+			continue
+		}
+		switch d.Name {
+		case DirectiveResponseContent:
+			d.Args[2] = og.model(pkg, d.Args[2])
+		case DirectiveRequestBody:
+			d.Args[1] = og.model(pkg, d.Args[1])
+		}
+		if fn, ok := opDirectives[d.Name]; ok {
+			fn(op, file, d.Args)
+		}
+		if d.Name == DirectiveParameter && !slices.Contains(parameterTypes, d.Args[2]) {
+			og.parameterType(&op.Parameters[len(op.Parameters)-1], d.Args[2], fset.Position(l.Pos()))
+		}
+		if d.Name == DirectiveResponseExample {
+			// Examples are sourced from the example file such that mismatches are reported there
+			ptr := Pointer("paths", path, strings.ToLower(method), "responses", d.Args[0], "examples", d.Args[1])
+			og.cfg.sources.add(ptr, token.Position{Filename: filepath.Join(filepath.Dir(file), d.Args[2]), Line: 1, Column: 1}, nil)
 		}
 	}
 
@@ -132,6 +131,37 @@ func (og *operationGenerator) operation(id, file, path, method string, doc *ast.
 	og.paths.Paths[path] = p // PathItem is value _not_ a ref reference so it has to be replaced
 }
 
+// model returns the component name of the model of a request or response, which for an
+// instantiation of a generic type is the name given when generating the schemas
+func (og *operationGenerator) model(pkg, expr string) string {
+	if name, ok := og.cfg.instances[instanceKey{pkg: pkg, expr: expr}]; ok {
+		return name
+	}
+	return expr
+}
+
+// parameterType resolves the type of a parameter referencing a definition, e.g., an enum, by
+// inlining the type, format and enum of the definition as parameters cannot reference schemas
+func (og *operationGenerator) parameterType(param *spec.Parameter, name string, pos token.Position) {
+	if og.cfg.definitions == nil {
+		og.cfg.report(Diagnostic{Pos: pos, Severity: SeverityError, Message: fmt.Sprintf("type %s of parameter %s cannot be resolved without the definitions of the specification", name, param.Name)})
+		return
+	}
+	def, ok := og.cfg.definitions[name]
+	if !ok || len(def.Type) != 1 || def.Type.Contains("object") || def.Type.Contains("array") {
+		og.cfg.report(Diagnostic{Pos: pos, Severity: SeverityError, Message: fmt.Sprintf("type %s of parameter %s is neither a primitive type nor a definition of a primitive type", name, param.Name)})
+		return
+	}
+	param.Type = def.Type[0]
+	if param.Format == "" {
+		param.Format = def.Format
+	}
+	param.Enum = def.Enum
+	for k, v := range def.Extensions {
+		param.AddExtension(k, v)
+	}
+}
+
 func handleParameter(op *spec.Operation, name, in, typ, format, description string) {
 	var param *spec.Parameter
 	if in == paramaterPath {
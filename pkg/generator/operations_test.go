@@ -14,17 +14,26 @@ func TestGenerateOperations(t *testing.T) {
 	}
 	pkgs, err := packages.Load(cfg, "./fixture/api/...")
 	if assert.NoError(t, err) {
-		paths := GenerateOperations(pkgs)
+		diagnostics := []Diagnostic{}
+		paths := GenerateOperations(pkgs, WithReporter(func(d Diagnostic) { diagnostics = append(diagnostics, d) }))
 		assert.Len(t, paths.Paths, 2)
-		assert.NotNil(t, paths.Paths["/entities"].Get)
+		require.NotNil(t, paths.Paths["/entities"].Get)
+		assert.Equal(t, "List entities", paths.Paths["/entities"].Get.Summary)
 
 		require.NotNil(t, paths.Paths["/entities/{id}"].Get)
-		assert.Len(t, paths.Paths["/entities/{id}"].Get.Responses.Default.Examples["application/ld+json"], 2)
+		assert.Len(t, paths.Paths["/entities/{id}"].Get.Responses.Default.Examples["application/ld+json"], 11)
 		assert.Len(t, paths.Paths["/entities/{id}"].Get.Produces, 2)
 
 		require.NotNil(t, paths.Paths["/entities/{id}"].Put)
+		assert.Empty(t, paths.Paths["/entities/{id}"].Put.Summary)
 		assert.Len(t, paths.Paths["/entities/{id}"].Put.Parameters, 2)
 
+		// Parameters of definition types are resolved only when generating the specification
+		if assert.Len(t, diagnostics, 1) {
+			assert.Equal(t, "type Status of parameter status cannot be resolved without the definitions of the specification", diagnostics[0].Message)
+			assert.Equal(t, SeverityError, diagnostics[0].Severity)
+		}
+
 		/*
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
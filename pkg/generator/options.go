@@ -0,0 +1,104 @@
+package generator
+
+import "github.com/go-openapi/spec"
+
+// Option configures the generation of the specification
+type Option func(*config)
+
+type config struct {
+	report  func(Diagnostic)
+	sources SourceMap
+
+	validateTags bool // Translate validate struct tags to JSON schema keywords
+	allOptional  bool // Do not infer required properties from Go types and json tags
+
+	genericSeparator string                  // Separator of the names of a generic type and its type arguments
+	typeMappings     map[string]*spec.Schema // Schemas of Go types given by import path and type name
+
+	definitions spec.Definitions       // Definitions used to resolve parameter types if known
+	instances   map[instanceKey]string // Component names of generic instantiations used by operations
+}
+
+// instanceKey identifies an instantiation of a generic type given by a directive as the type
+// expression and the package in which it is resolved
+type instanceKey struct {
+	pkg  string
+	expr string
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		report: logDiagnostic,
+	}
+	for _, o := range opts {
+		o(cfg)
+	}
+	return cfg
+}
+
+// WithReporter sets the function receiving diagnostics found during generation. By
+// default diagnostics are logged.
+func WithReporter(fn func(Diagnostic)) Option {
+	return func(c *config) {
+		c.report = fn
+	}
+}
+
+// WithSourceMap sets a source map which will be populated with the source positions and
+// openapi:nolint directives of the operations, schemas and properties generated
+func WithSourceMap(sm SourceMap) Option {
+	return func(c *config) {
+		c.sources = sm
+	}
+}
+
+// WithValidateTags enables translating the rules of go-playground/validator `validate` struct
+// tags into the corresponding JSON schema keywords, e.g., `required`, `min` and `oneof`
+func WithValidateTags() Option {
+	return func(c *config) {
+		c.validateTags = true
+	}
+}
+
+// WithAllOptional disables inferring required properties from the Go types and json tag
+// options of fields, i.e., only properties marked by schema:required or a validate tag are
+// required
+func WithAllOptional() Option {
+	return func(c *config) {
+		c.allOptional = true
+	}
+}
+
+// WithGenericSeparator sets the separator between the component name of a generic type and the
+// names of its type arguments forming the component name of an instantiation, e.g., `_` names
+// the instantiation Page[Model] as Page_Model rather than the default PageModel
+func WithGenericSeparator(sep string) Option {
+	return func(c *config) {
+		c.genericSeparator = sep
+	}
+}
+
+// WithTypeMappings sets the schemas of Go types identified by import path and type name, e.g.,
+// github.com/oklog/ulid/v2.ULID, overriding the schemas inferred wherever the types are used.
+// The mappings take precedence over openapi:typeMapping directives.
+func WithTypeMappings(mappings map[string]*spec.Schema) Option {
+	return func(c *config) {
+		c.typeMappings = mappings
+	}
+}
+
+// withDefinitions makes the generated definitions available for resolving parameter types
+// referencing definitions
+func withDefinitions(defs spec.Definitions) Option {
+	return func(c *config) {
+		c.definitions = defs
+	}
+}
+
+// withInstances shares the component names of the generic instantiations referenced by
+// operations between generating schemas and operations
+func withInstances(instances map[instanceKey]string) Option {
+	return func(c *config) {
+		c.instances = instances
+	}
+}
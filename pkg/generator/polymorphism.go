@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// interfaceSchema renders an interface type annotated by openapi:discriminator as a base
+// schema with the discriminator property. OpenAPI 2.0 models polymorphism by the schemas of
+// the implementing types extending the base schema using allOf, the value of the
+// discriminator property being the name of the schema of the implementing type. The
+// implementing types are given by openapi:oneOf or found among the loaded packages.
+func (sg *schemaGenerator) interfaceSchema(named *types.Named, iface *types.Interface, doc *ast.CommentGroup) *spec.Schema {
+	discriminator := ""
+	for _, d := range directives(doc, DirectiveDiscriminator) {
+		discriminator = d.Args[0]
+	}
+	oneOf := []string{}
+	for _, d := range directives(doc, DirectiveOneOf) {
+		oneOf = append(oneOf, strings.Fields(d.Args[0])...)
+	}
+	if discriminator == "" {
+		if len(oneOf) > 0 {
+			sg.report(named.Obj().Pos(), SeverityWarning, "openapi:oneOf of %s is ignored as OpenAPI 2.0 requires an openapi:discriminator to express polymorphism", named.Obj().Name())
+		}
+		return nil
+	}
+
+	var implementations []*types.Named
+	if len(oneOf) > 0 {
+		for _, name := range oneOf {
+			impl := sg.lookupType(name)
+			if impl == nil {
+				sg.report(named.Obj().Pos(), SeverityError, "type %s of openapi:oneOf of %s is not found", name, named.Obj().Name())
+				continue
+			}
+			if !types.Implements(impl, iface) && !types.Implements(types.NewPointer(impl), iface) {
+				sg.report(named.Obj().Pos(), SeverityError, "type %s of openapi:oneOf does not implement %s", name, named.Obj().Name())
+				continue
+			}
+			implementations = append(implementations, impl)
+		}
+	} else {
+		implementations = sg.implementations(named, iface)
+	}
+	if len(implementations) == 0 {
+		sg.report(named.Obj().Pos(), SeverityWarning, "no implementations of %s found", named.Obj().Name())
+	}
+
+	base := sg.typeName(named)
+	values := []any{}
+	for _, impl := range implementations {
+		if _, ok := impl.Underlying().(*types.Struct); !ok {
+			sg.report(impl.Obj().Pos(), SeverityWarning, "implementation %s of %s is left out as only structs can extend the schema of %s", impl.Obj().Name(), named.Obj().Name(), base)
+			continue
+		}
+		sg.ref(impl)
+		name := sg.typeName(impl)
+		sg.supertypes[name] = append(sg.supertypes[name], base)
+		values = append(values, name)
+	}
+
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: map[string]spec.Schema{discriminator: *spec.StringProperty().WithEnum(values...)},
+			Required:   []string{discriminator},
+		},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{
+			Discriminator: discriminator,
+		},
+	}
+	if doc != nil {
+		schema.WithDescription(strings.TrimSpace(doc.Text()))
+	}
+	return schema
+}
+
+// extendSupertypes makes the schemas of implementations of polymorphic interfaces extend the
+// schemas of the interfaces, this is done when all schemas are generated as the schema of an
+// implementation may be generated while generating the schema of the interface
+func (sg *schemaGenerator) extendSupertypes() {
+	for _, name := range slices.Sorted(maps.Keys(sg.supertypes)) {
+		schema, ok := sg.schemas[name]
+		if !ok {
+			continue
+		}
+		extended := &spec.Schema{}
+		for _, base := range sg.supertypes[name] {
+			extended.AllOf = append(extended.AllOf, *spec.RefSchema(fmt.Sprintf("#%s/%s", refPrefix, base)))
+		}
+		extended.Description, schema.Description = schema.Description, ""
+		extended.AllOf = append(extended.AllOf, *schema)
+		sg.schemas[name] = extended
+	}
+}
+
+// implementations returns the defined types of the loaded packages and the package of the
+// interface implementing the interface either directly or through a pointer
+func (sg *schemaGenerator) implementations(named *types.Named, iface *types.Interface) []*types.Named {
+	if iface.NumMethods() == 0 {
+		return nil // Everything implements the empty interface
+	}
+
+	pkgs := slices.Clone(sg.roots)
+	if pkg := named.Obj().Pkg(); pkg != nil && !slices.Contains(pkgs, pkg) {
+		pkgs = append(pkgs, pkg)
+	}
+
+	implementations := []*types.Named{}
+	for _, pkg := range pkgs {
+		for _, name := range pkg.Scope().Names() {
+			tn, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+			impl, ok := tn.Type().(*types.Named)
+			if !ok || impl.TypeParams().Len() > 0 || types.IsInterface(impl) {
+				continue
+			}
+			if types.Implements(impl, iface) || types.Implements(types.NewPointer(impl), iface) {
+				implementations = append(implementations, impl)
+			}
+		}
+	}
+	slices.SortFunc(implementations, func(a, b *types.Named) int {
+		return cmp.Or(cmp.Compare(a.Obj().Pkg().Path(), b.Obj().Pkg().Path()), cmp.Compare(a.Obj().Pos(), b.Obj().Pos()))
+	})
+	return implementations
+}
+
+// lookupType finds the defined type by component name or type name among the loaded packages
+func (sg *schemaGenerator) lookupType(name string) *types.Named {
+	for obj, component := range sg.names {
+		if component == name {
+			named, _ := obj.Type().(*types.Named)
+			return named
+		}
+	}
+	for _, pkg := range slices.Concat(sg.roots, sg.packages) {
+		if tn, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok {
+			if named, ok := tn.Type().(*types.Named); ok {
+				return named
+			}
+		}
+	}
+	return nil
+}
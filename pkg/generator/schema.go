@@ -1,58 +1,126 @@
 package generator
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
 	"go/ast"
+	"go/constant"
+	"go/token"
 	"go/types"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-openapi/spec"
-	"github.com/rs/zerolog/log"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
-const refPrefix = "/definitions"
-
-var (
-	openapiComponentExp = regexp.MustCompile(`^//openapi:component schema (\w+)$`)
-
-	schemaExampleExp = regexp.MustCompile("^//(openapi|schema):example (.*)$")
-	schemaFormatExp  = regexp.MustCompile("^//(openapi|schema):format (.*)$")
-	schemaDefaultExp = regexp.MustCompile("^//(openapi|schema):default (.*)$")
+const (
+	refPrefix = "/definitions"
+
+	// extNullable marks schemas allowing null as OpenAPI 2.0 has no notion of null
+	extNullable = "x-nullable"
+	// extWriteOnly marks properties only used in requests as OpenAPI 2.0 only has readOnly,
+	// extension names are lower case as they are normalized by go-openapi
+	extWriteOnly = "x-writeonly"
+	// extKeyType describes the keys of maps as OpenAPI 2.0 has no propertyNames
+	extKeyType = "x-key-type"
+	// extGoType names the Go type of integers with a range not covered by the standard formats
+	extGoType = "x-go-type"
 )
 
+// simpleTypeMap maps the basic types serialized by encoding/json to schemas with formats wide
+// enough for the range of the type. Unsigned integers have minimum 0 and unsigned integers of
+// 64 bits have no format as no standard format covers their range, rather the Go type is given
+// by an extension. Complex numbers and unsafe pointers cannot be serialized and are left out.
 var simpleTypeMap = map[types.BasicKind]func() *spec.Schema{
 	types.Bool:    spec.BoolProperty,
-	types.Int:     spec.Int32Property,
+	types.Int:     spec.Int64Property,
 	types.Int8:    spec.Int8Property,
 	types.Int16:   spec.Int16Property,
-	types.Int32:   spec.Int32Property,
+	types.Int32:   spec.Int32Property, // Also rune
 	types.Int64:   spec.Int64Property,
-	types.Uint:    spec.Int32Property,
-	types.Uint8:   spec.Int8Property,
-	types.Uint16:  spec.Int16Property,
-	types.Uint32:  spec.Int32Property,
-	types.Uint64:  spec.Int64Property,
+	types.Uint:    unsigned(goType("uint")),
+	types.Uint8:   unsigned(spec.Int16Property), // Also byte
+	types.Uint16:  unsigned(spec.Int32Property),
+	types.Uint32:  unsigned(spec.Int64Property),
+	types.Uint64:  unsigned(goType("uint64")),
+	types.Uintptr: unsigned(goType("uintptr")),
 	types.Float32: spec.Float32Property,
 	types.Float64: spec.Float64Property,
 	types.String:  spec.StringProperty,
 }
 
-var jsonTag = regexp.MustCompile(`json:"([^"]*)"`)
+// goType returns an integer schema without a format naming the Go type by an extension
+func goType(name string) func() *spec.Schema {
+	return func() *spec.Schema {
+		schema := jsonType("integer")()
+		schema.AddExtension(extGoType, name)
+		return schema
+	}
+}
+
+// unsigned adds minimum 0 to the schema of an integer type
+func unsigned(fn func() *spec.Schema) func() *spec.Schema {
+	return func() *spec.Schema {
+		return fn().WithMinimum(0, false)
+	}
+}
 
-func GenerateSchemas(pkgs []*packages.Package) map[string]*spec.Schema {
+func GenerateSchemas(pkgs []*packages.Package, opts ...Option) map[string]*spec.Schema {
 	sg := &schemaGenerator{
-		schemas: map[string]*spec.Schema{},
+		cfg:        newConfig(opts),
+		schemas:    map[string]*spec.Schema{},
+		broken:     map[string]bool{},
+		names:      map[*types.TypeName]string{},
+		supertypes: map[string][]string{},
 	}
 	return sg.Generate(pkgs)
 }
 
 type schemaGenerator struct {
+	cfg     *config
 	schemas map[string]*spec.Schema
+	fset    *token.FileSet
+	files   []*ast.File     // All files of loaded packages including dependencies sorted by position
+	broken  map[string]bool // Paths of packages with load or type-check errors
+
+	roots    []*types.Package // Packages given for generation
+	packages []*types.Package // All loaded packages including dependencies
+
+	names      map[*types.TypeName]string // Component name of named types
+	seen       typeutil.Map               // Named types and instantiations being or already generated
+	supertypes map[string][]string        // Schemas of polymorphic interfaces extended by a schema
+	mappings   map[string]*spec.Schema    // Schemas of Go types given by import path and type name
 }
 
 func (sg *schemaGenerator) Generate(pkgs []*packages.Package) map[string]*spec.Schema {
+	for _, p := range pkgs {
+		if p.Types != nil {
+			sg.roots = append(sg.roots, p.Types)
+		}
+	}
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		sg.fset = p.Fset
+		sg.files = append(sg.files, p.Syntax...)
+		if p.Types != nil {
+			sg.packages = append(sg.packages, p.Types)
+		}
+		if len(p.Errors) > 0 || len(p.TypeErrors) > 0 {
+			sg.broken[p.PkgPath] = true
+		}
+	})
+	slices.SortFunc(sg.files, func(a, b *ast.File) int { return cmp.Compare(a.FileStart, b.FileStart) })
+	sg.mappings = sg.typeMappings(pkgs)
+
+	// Component names are collected up front such that references to a component are
+	// named consistently no matter if the reference is met before the component itself
+	components := []*types.Named{}
 	for _, p := range pkgs {
 		for _, f := range p.Syntax { // Entry for each file in package
 			for _, d := range f.Decls {
@@ -68,214 +136,806 @@ func (sg *schemaGenerator) Generate(pkgs []*packages.Package) map[string]*spec.S
 					}
 
 					componentID := ""
-					if ts.Doc != nil {
-						for _, cmt := range ts.Doc.List {
-							m := openapiComponentExp.FindStringSubmatch(cmt.Text)
-							if m != nil {
-								componentID = m[1]
-							}
-						}
+					for _, d := range directives(ts.Doc, DirectiveComponent) {
+						componentID = d.Args[1]
 					}
-					if componentID == "" && gd.Doc != nil {
-						for _, cmt := range gd.Doc.List {
-							m := openapiComponentExp.FindStringSubmatch(cmt.Text)
-							if m != nil {
-								componentID = m[1]
-							}
+					if componentID == "" {
+						for _, d := range directives(gd.Doc, DirectiveComponent) {
+							componentID = d.Args[1]
 						}
 					}
 
 					if componentID != "" { // Component was identified
-						description := ""
-						if ts.Doc != nil {
-							description = ts.Doc.Text()
-						} else if gd.Doc != nil {
-							description = gd.Doc.Text()
+						var obj *types.TypeName
+						if p.TypesInfo != nil {
+							obj, _ = p.TypesInfo.Defs[ts.Name].(*types.TypeName)
+						}
+						if obj == nil {
+							sg.report(ts.Pos(), SeverityError, "component %s is left out as no type information is found for %s - check package errors", componentID, ts.Name)
+							continue
+						}
+						named, ok := obj.Type().(*types.Named)
+						if !ok {
+							sg.report(ts.Pos(), SeverityWarning, "component %s is left out as only defined types can be components", componentID)
+							continue
+						}
+						sg.names[obj] = componentID
+						if named.TypeParams().Len() == 0 { // Generic types are generated per instantiation
+							components = append(components, named)
 						}
-
-						schema := sg.schema(p, ts, description)
-						// if schema == nil --> ERROR
-						// TODO: Check for existing schema!!
-						sg.schemas[componentID] = schema
 					}
-
 				}
 			}
 		}
 	}
 
+	for _, named := range components {
+		if sg.seen.At(named) == nil { // May already be generated as a reference from another component
+			sg.define(named)
+		}
+	}
+	for _, p := range pkgs {
+		sg.operationInstances(p)
+	}
+	sg.extendSupertypes()
+
 	return sg.schemas
 }
 
-func (sg *schemaGenerator) schema(p *packages.Package, ts *ast.TypeSpec, description string) *spec.Schema {
-	def, ok := p.TypesInfo.Defs[ts.Name]
-	if !ok {
-		log.Warn().Str("type", ts.Name.String()).Msg("No type info found")
-		return nil
+// define generates the schema for the named type and registers it as a component. The
+// type is marked as seen before the schema is generated such that recursive references
+// will become references to the component rather than being expanded again.
+func (sg *schemaGenerator) define(named *types.Named) {
+	obj := named.Obj()
+	name := sg.typeName(named)
+	sg.seen.Set(named, true)
+
+	if obj.Pkg() != nil && sg.broken[obj.Pkg().Path()] {
+		sg.report(obj.Pos(), SeverityWarning, "component %s may be incomplete as package %s has errors", name, obj.Pkg().Path())
 	}
 
-	properties := map[string]spec.Schema{}
+	doc := sg.typeDoc(obj)
+	ptr := Pointer("definitions", name)
+	sg.cfg.sources.add(ptr, sg.position(obj.Pos()), doc)
+
+	schema := sg.schema(named, doc, ptr)
+	if schema == nil {
+		sg.report(obj.Pos(), SeverityError, "component %s is left out as the type %s cannot be rendered", name, named)
+		return
+	}
+	if _, exists := sg.schemas[name]; exists {
+		if named.TypeArgs().Len() > 0 {
+			sg.report(obj.Pos(), SeverityError, "component name %s of %s is already in use by another type - overwriting", name, named)
+		} else {
+			sg.report(obj.Pos(), SeverityWarning, "component name %s already in use - overwriting", name)
+		}
+	}
+	sg.schemas[name] = schema
+}
+
+// ref returns a reference to the component for the named type generating the component
+// if not already done.
+func (sg *schemaGenerator) ref(named *types.Named) *spec.Schema {
+	if sg.seen.At(named) == nil {
+		sg.define(named)
+	}
+	return spec.RefSchema(fmt.Sprintf("#%s/%s", refPrefix, sg.typeName(named)))
+}
+
+func (sg *schemaGenerator) componentName(obj *types.TypeName) string {
+	if name, ok := sg.names[obj]; ok {
+		return name
+	}
+	return obj.Name()
+}
+
+func (sg *schemaGenerator) schema(named *types.Named, doc *ast.CommentGroup, ptr string) *spec.Schema {
+	if schema := sg.override(named, doc); schema != nil {
+		return schema
+	}
+	if schema := sg.mapped(named.Obj()); schema != nil {
+		if doc != nil && schema.Description == "" {
+			schema.WithDescription(strings.TrimSpace(doc.Text()))
+		}
+		return schema
+	}
+
+	// encoding/json prefers MarshalJSON over MarshalText
+	if marshals(named, "MarshalJSON") {
+		sg.report(named.Obj().Pos(), SeverityWarning, "type %s implements json.Marshaler and the schema inferred from the Go type may not match its JSON - describe the JSON using openapi:schemaType or openapi:schema", named.Obj().Name())
+	} else if marshals(named, "MarshalText") {
+		schema := spec.StringProperty()
+		if a, ok := named.Underlying().(*types.Array); ok && byteArrayFormat(a, doc) != "" {
+			schema = byteArraySchema(a, byteArrayFormat(a, doc))
+		}
+		if doc != nil {
+			schema.WithDescription(strings.TrimSpace(doc.Text()))
+		}
+		return schema
+	}
 
 	var schema *spec.Schema
-	switch ut := def.Type().Underlying().(type) {
-	case *types.Struct: // If this is not a struct? Only structs qualify for components??
-		for i := 0; i < ut.NumFields(); i++ {
-			field := ut.Field(i)
-			if field.Exported() || field.Embedded() {
-				astStruct, ok := ts.Type.(*ast.StructType)
-				if !ok {
-					// Only structs qualify as components - DO WARNING!!
-					continue
-				}
-				astField := astStruct.Fields.List[i] // Expect the order to match the types fields
-
-				propertyName := field.Name()
-				tags := ut.Tag(i)
-				m := jsonTag.FindStringSubmatch(tags)
-				if m != nil {
-					field, _, _ := strings.Cut(m[1], ",") // Consider if json tag options may ve relevant
-					if field != "" {
-						if field == "-" {
-							continue
-						}
-						propertyName = field
-					}
-				}
+	switch ut := named.Underlying().(type) {
+	case *types.Struct:
+		schema = sg.structSchema(ut, ptr)
+		if doc != nil {
+			schema.WithDescription(strings.TrimSpace(doc.Text()))
+		}
+		for _, d := range directives(doc, DirectiveNullable) {
+			schema.AddExtension(extNullable, d.Args[0] != "false")
+		}
 
-				props := sg.handleField(p, field.Type(), propertyName, field.Embedded(), astField.Doc)
-				for k, v := range props {
-					properties[k] = v
-				}
-			}
+	case *types.Basic:
+		schema = sg.handleField(ut, ptr, doc)
+		if schema != nil {
+			sg.enum(named, schema)
 		}
-		schema = &spec.Schema{
-			SchemaProps: spec.SchemaProps{Type: []string{"object"}},
+
+	case *types.Interface:
+		schema = sg.interfaceSchema(named, ut, doc)
+		if schema == nil {
+			schema = sg.handleField(ut, ptr, doc)
 		}
 
 	default:
-		props := sg.handleField(p, def.Type().Underlying(), def.Name(), false, ts.Doc)
-		prop := props[def.Name()]
-		schema = &prop
+		schema = sg.handleField(ut, ptr, doc)
 	}
 
-	return schema.
-		WithDescription(strings.TrimSpace(description)).
-		WithProperties(properties)
+	return schema
 }
 
-func (sg *schemaGenerator) handleField(p *packages.Package, t types.Type, name string, embedded bool, doc *ast.CommentGroup) map[string]spec.Schema {
-	var prop *spec.Schema
-	switch fieldType := t.(type) {
-	case *types.Basic:
-		if fn, ok := simpleTypeMap[fieldType.Kind()]; ok {
-			prop = fn()
-		} else {
-			log.Warn().Int("kind", int(fieldType.Kind())).Msg("Unable to map go simple type to json schema type")
-			prop = spec.StringProperty()
+// enum adds the package level constants of the named type as enum values of the schema. The
+// documentation and names of the constants are added as x-enum-descriptions and
+// x-enum-varnames respectively.
+func (sg *schemaGenerator) enum(named *types.Named, schema *spec.Schema) {
+	pkg := named.Obj().Pkg()
+	if pkg == nil {
+		return
+	}
+
+	consts := []*types.Const{}
+	for _, name := range pkg.Scope().Names() {
+		if c, ok := pkg.Scope().Lookup(name).(*types.Const); ok && types.Identical(c.Type(), named) {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		return
+	}
+	slices.SortFunc(consts, func(a, b *types.Const) int { return cmp.Compare(a.Pos(), b.Pos()) })
+
+	names, descriptions := []string{}, []string{}
+	described := false
+	for _, c := range consts {
+		value := constantValue(c.Val())
+		if value == nil {
+			sg.report(c.Pos(), SeverityWarning, "constant %s is left out of the enum of %s as its value cannot be rendered", c.Name(), named.Obj().Name())
+			continue
 		}
+		schema.Enum = append(schema.Enum, value)
+		names = append(names, c.Name())
+		description := ""
+		if doc := sg.constDoc(c); doc != nil {
+			description = strings.TrimSpace(doc.Text())
+			described = true
+		}
+		descriptions = append(descriptions, description)
+	}
 
-	case *types.Named:
-		prop = checkKnownTypes(fieldType.Obj())
-		if prop != nil {
-			break // break from the switch
+	schema.AddExtension("x-enum-varnames", names)
+	if described {
+		schema.AddExtension("x-enum-descriptions", descriptions)
+	}
+}
+
+// constantValue converts the constant value to the corresponding JSON value
+func constantValue(v constant.Value) any {
+	switch v.Kind() {
+	case constant.String:
+		return constant.StringVal(v)
+	case constant.Bool:
+		return constant.BoolVal(v)
+	case constant.Int:
+		if i, ok := constant.Int64Val(v); ok {
+			return i
 		}
+		if u, ok := constant.Uint64Val(v); ok {
+			return u
+		}
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return f
+	}
+	return nil
+}
 
-		var pkg *packages.Package
-		if fieldType.Obj().Pkg() == p.Types {
-			pkg = p
-		} else {
-			pkg = p.Imports[fieldType.Obj().Pkg().Path()]
+// structSchema renders the struct as an object schema with a property for each field
+// which encoding/json would serialize
+func (sg *schemaGenerator) structSchema(st *types.Struct, ptr string) *spec.Schema {
+	properties := map[string]spec.Schema{}
+	var required []string
+	var additional *spec.SchemaOrBool
+	for _, f := range jsonFields(st) {
+		if unknown(f) {
+			additional = sg.unknownMembers(f, ptr)
+			continue
 		}
 
-		typeSpec := findTypeSpec(pkg, fieldType.Obj().Name())
-		if typeSpec != nil {
-			sch := sg.schema(pkg, typeSpec, doc.Text())
+		doc := sg.fieldDoc(f.v)
+		sg.cfg.sources.add(ptr+Pointer("properties", f.name), sg.position(f.v.Pos()), doc)
 
-			if embedded {
-				return sch.Properties
+		prop := sg.handleField(f.v.Type(), ptr+Pointer("properties", f.name), doc)
+		if prop != nil {
+			prop = sg.jsonOptions(prop, f)
+		}
+		if prop == nil {
+			if f.v.Pkg() != nil && sg.broken[f.v.Pkg().Path()] {
+				sg.report(f.v.Pos(), SeverityError, "property %s is left out as its type cannot be resolved due to package errors", f.name)
+			} else if err := mapKeyError(f.v.Type()); err != nil {
+				sg.report(f.v.Pos(), SeverityError, "property %s is left out as %s", f.name, err)
 			} else {
-				// TODO: Check it already exists...
-				sg.schemas[fieldType.Obj().Name()] = sch
-				prop = spec.RefSchema(fmt.Sprintf("#%s/%s", refPrefix, fieldType.Obj().Name()))
+				sg.report(f.v.Pos(), SeverityWarning, "property %s is left out as the type %s cannot be rendered", f.name, f.v.Type())
 			}
+			continue
+		}
+		if _, explicit := prop.Extensions[extNullable]; !explicit && nullable(f) {
+			prop.AddExtension(extNullable, true)
+		}
+		validated := sg.cfg.validateTags && sg.validateTag(prop, f.v.Type(), f.tag, f.v.Pos())
+		if sg.required(f, prop, doc, validated) {
+			required = append(required, f.name)
+		}
+		properties[f.name] = *prop
+	}
 
-		} else {
-			log.Warn().Str("package", pkg.String()).Str("type", fieldType.Obj().Name()).Msg("Unable to find ast type specification")
+	return &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:                 []string{"object"},
+			Properties:           properties,
+			Required:             required,
+			AdditionalProperties: additional,
+		},
+	}
+}
+
+// nullable returns true if the field is a pointer which is serialized as null rather than
+// omitted if nil
+func nullable(f jsonField) bool {
+	_, isPointer := types.Unalias(f.v.Type()).(*types.Pointer)
+	return isPointer && !omitted(f)
+}
+
+// omitted returns true if the json tag options omit the field if empty or zero. The option
+// omitempty has no effect on structs as they are never empty.
+func omitted(f jsonField) bool {
+	if hasOption(f.options, "omitzero") {
+		return true
+	}
+	_, isStruct := f.v.Type().Underlying().(*types.Struct)
+	return hasOption(f.options, "omitempty") && !isStruct
+}
+
+// required decides if the property of the field is required. Fields which are not pointers
+// and do not have the json tag options omitempty or omitzero are required unless inference
+// is disabled or the property is readOnly. The required rule of the validate tag and the
+// schema:required and schema:optional directives take precedence in that order.
+func (sg *schemaGenerator) required(f jsonField, prop *spec.Schema, doc *ast.CommentGroup, validated bool) bool {
+	required := false
+	if !sg.cfg.allOptional && !prop.ReadOnly {
+		_, isPointer := types.Unalias(f.v.Type()).(*types.Pointer)
+		required = !isPointer && !f.pointer && !omitted(f)
+	}
+	if validated {
+		required = true
+	}
+	if doc != nil {
+		for _, c := range doc.List {
+			if d, err := ParseDirective(c.Text); err == nil && d != nil && d.Items == 0 {
+				switch d.Name {
+				case DirectiveRequired:
+					required = true
+				case DirectiveOptional:
+					required = false
+				}
+			}
+		}
+	}
+	return required
+}
+
+// handleField generates the schema of a field of the given type, the pointer locates the schema
+// within the specification
+func (sg *schemaGenerator) handleField(t types.Type, ptr string, doc *ast.CommentGroup) *spec.Schema {
+	// Aliases are rendered as the aliased type unless the alias itself is mapped or known, e.g.,
+	// json.RawMessage which is an alias of jsontext.Value using encoding/json v2
+	if alias, ok := t.(*types.Alias); ok {
+		if prop := sg.predefined(alias.Obj()); prop != nil {
+			if doc != nil {
+				sg.handleGodoc(prop, t, doc)
+			}
+			return prop
+		}
+	}
+
+	var prop *spec.Schema
+	switch fieldType := types.Unalias(t).(type) {
+	case *types.Basic:
+		fn, ok := simpleTypeMap[fieldType.Kind()]
+		if !ok { // Invalid types due to errors, complex numbers and unsafe pointers
+			return nil
+		}
+		prop = fn()
+
+	case *types.Named:
+		prop = sg.predefined(fieldType.Obj())
+		if prop != nil {
+			break // break from the switch
 		}
 
+		prop = sg.ref(fieldType)
+
 	case *types.Slice:
-		elementProps := sg.handleField(p, fieldType.Elem(), "item", false, nil)
-		elSchema := elementProps["item"]
-		prop = spec.ArrayProperty(&elSchema)
+		if isBytes(fieldType) {
+			prop = spec.StrFmtProperty("byte")
+			break
+		}
+		elSchema := sg.handleField(fieldType.Elem(), ptr+Pointer("items"), nil)
+		if elSchema == nil {
+			return nil
+		}
+		prop = spec.ArrayProperty(elSchema)
+
+	case *types.Array:
+		if format := byteArrayFormat(fieldType, doc); format != "" {
+			sg.report(doc.Pos(), SeverityWarning, "format %s is ignored as %s is serialized as an array of numbers unless encoded by encoding.TextMarshaler", format, fieldType)
+		}
+		elSchema := sg.handleField(fieldType.Elem(), ptr+Pointer("items"), nil)
+		if elSchema == nil {
+			return nil
+		}
+		prop = spec.ArrayProperty(elSchema).WithMinItems(fieldType.Len()).WithMaxItems(fieldType.Len())
 
 	case *types.Map:
-		elementProps := sg.handleField(p, fieldType.Elem(), "item", false, nil)
-		elSchema := elementProps["item"]
-		prop = spec.MapProperty(&elSchema)
+		if mapKeyError(fieldType) != nil {
+			return nil
+		}
+		elSchema := sg.handleField(fieldType.Elem(), ptr+Pointer("additionalProperties"), nil)
+		if elSchema == nil {
+			return nil
+		}
+		prop = spec.MapProperty(elSchema)
+		if key := sg.keySchema(fieldType.Key(), ptr); key != nil {
+			prop.AddExtension(extKeyType, key)
+		}
 
 	case *types.Pointer:
-		return sg.handleField(p, fieldType.Elem(), name, embedded, doc)
+		return sg.handleField(fieldType.Elem(), ptr, doc)
+
+	case *types.Struct: // Anonymous struct
+		prop = sg.structSchema(fieldType, ptr)
 
 	case *types.Interface:
 		prop = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
 
-	default:
-		log.Warn().Str("field", name).Type("type", t).Msg("Unsupported type for field")
-		return map[string]spec.Schema{}
+	default: // Reported by the caller leaving out the property
+		return nil
 	}
 
 	if doc != nil {
-		handleGodoc(prop, doc)
+		sg.handleGodoc(prop, t, doc)
 	}
 
-	properties := map[string]spec.Schema{}
-	properties[name] = *prop
-	return properties
+	return prop
 }
 
-func handleGodoc(prop *spec.Schema, doc *ast.CommentGroup) *spec.Schema {
+// schemaDirectives apply the schema directives to the schema of a field of the given type.
+// An error is returned if the directive cannot be applied to the field.
+var schemaDirectives = map[string]func(prop *spec.Schema, t types.Type, arg string) error{
+	DirectiveExample: func(prop *spec.Schema, _ types.Type, arg string) error {
+		prop.WithExample(exampleValue(prop, arg))
+		return nil
+	},
+	DirectiveFormat: func(prop *spec.Schema, t types.Type, arg string) error {
+		if isByteArray(t) && (arg == "byte" || arg == "hex") { // Applied only by encoding.TextMarshaler
+			return nil
+		}
+		prop.Format = arg
+		return nil
+	},
+	DirectiveDefault: func(prop *spec.Schema, _ types.Type, arg string) error {
+		prop.WithDefault(arg)
+		return nil
+	},
+	DirectiveMinimum: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := numericValue(t, arg)
+		if err == nil {
+			prop.Minimum = &v
+		}
+		return err
+	},
+	DirectiveMaximum: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := numericValue(t, arg)
+		if err == nil {
+			prop.Maximum = &v
+		}
+		return err
+	},
+	// OpenAPI 2.0 declares exclusive bounds as a flag on the bound
+	DirectiveExclusiveMinimum: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := numericValue(t, arg)
+		if err == nil {
+			prop.WithMinimum(v, true)
+		}
+		return err
+	},
+	DirectiveExclusiveMaximum: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := numericValue(t, arg)
+		if err == nil {
+			prop.WithMaximum(v, true)
+		}
+		return err
+	},
+	DirectiveMultipleOf: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := numericValue(t, arg)
+		if err == nil && v <= 0 {
+			err = fmt.Errorf("multipleOf must be greater than 0")
+		}
+		if err == nil {
+			prop.WithMultipleOf(v)
+		}
+		return err
+	},
+	DirectiveMinLength: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := lengthValue(t, arg)
+		if err == nil {
+			prop.WithMinLength(v)
+		}
+		return err
+	},
+	DirectiveMaxLength: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := lengthValue(t, arg)
+		if err == nil {
+			prop.WithMaxLength(v)
+		}
+		return err
+	},
+	DirectivePattern: func(prop *spec.Schema, t types.Type, arg string) error {
+		if b := basicType(t); b == nil || b.Info()&types.IsString == 0 {
+			return fmt.Errorf("the type %s is not a string", t)
+		}
+		if _, err := regexp.Compile(arg); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		prop.WithPattern(arg)
+		return nil
+	},
+	DirectiveMinItems: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := countValue(t, arg)
+		if err == nil {
+			prop.WithMinItems(v)
+		}
+		return err
+	},
+	DirectiveMaxItems: func(prop *spec.Schema, t types.Type, arg string) error {
+		v, err := countValue(t, arg)
+		if err == nil {
+			prop.WithMaxItems(v)
+		}
+		return err
+	},
+	DirectiveNullable: func(prop *spec.Schema, _ types.Type, arg string) error {
+		prop.AddExtension(extNullable, arg != "false")
+		return nil
+	},
+	DirectiveReadOnly: func(prop *spec.Schema, _ types.Type, arg string) error {
+		if writeOnly, _ := prop.Extensions.GetBool(extWriteOnly); writeOnly && arg != "false" {
+			return fmt.Errorf("a property cannot be both readOnly and writeOnly")
+		}
+		prop.ReadOnly = arg != "false"
+		return nil
+	},
+	DirectiveWriteOnly: func(prop *spec.Schema, _ types.Type, arg string) error {
+		if prop.ReadOnly && arg != "false" {
+			return fmt.Errorf("a property cannot be both readOnly and writeOnly")
+		}
+		prop.AddExtension(extWriteOnly, arg != "false")
+		return nil
+	},
+	DirectiveUniqueItems: func(prop *spec.Schema, t types.Type, arg string) error {
+		if !isSlice(t) {
+			return fmt.Errorf("the type %s is not a slice", t)
+		}
+		prop.UniqueItems = arg != "false"
+		return nil
+	},
+}
+
+// handleGodoc applies the description and the schema directives of the godoc to the schema
+// of a field of the given type
+func (sg *schemaGenerator) handleGodoc(prop *spec.Schema, t types.Type, doc *ast.CommentGroup) *spec.Schema {
 	prop.Description = strings.TrimSpace(doc.Text())
 
 	for _, c := range doc.List {
-		exampleMatch := schemaExampleExp.FindStringSubmatch(c.Text)
-		if exampleMatch != nil {
-			prop = prop.WithExample(exampleMatch[2])
+		d, err := ParseDirective(c.Text)
+		if err != nil || d == nil {
+			continue
 		}
 
-		formatMatch := schemaFormatExp.FindStringSubmatch(c.Text)
-		if formatMatch != nil {
-			prop.Format = formatMatch[2]
+		if fn, ok := schemaDirectives[d.Name]; ok {
+			target, tt, err := itemsSchema(prop, t, d.Items)
+			if err == nil && constraintDirectives[d.Name] {
+				err = referenceError(target, tt)
+			}
+			if err == nil {
+				err = fn(target, tt, d.Args[0])
+			}
+			if err != nil {
+				sg.report(c.Pos(), SeverityError, "directive %s:%s%s is ignored: %s", d.Prefix, strings.Repeat(itemsPrefix, d.Items), d.Name, err)
+			}
+		}
+	}
+
+	return prop
+}
+
+// constraintDirectives are the directives adding validation keywords which are ignored next to
+// a reference in OpenAPI 2.0
+var constraintDirectives = map[string]bool{
+	DirectiveMinimum:          true,
+	DirectiveMaximum:          true,
+	DirectiveExclusiveMinimum: true,
+	DirectiveExclusiveMaximum: true,
+	DirectiveMultipleOf:       true,
+	DirectiveMinLength:        true,
+	DirectiveMaxLength:        true,
+	DirectivePattern:          true,
+	DirectiveMinItems:         true,
+	DirectiveMaxItems:         true,
+	DirectiveUniqueItems:      true,
+}
+
+// referenceError returns an error if the schema is a reference as keywords next to $ref are
+// ignored, the type must be annotated instead
+func referenceError(prop *spec.Schema, t types.Type) error {
+	if prop.Ref.String() != "" {
+		return fmt.Errorf("the type %s is rendered as a reference - annotate the type instead", t)
+	}
+	return nil
+}
+
+// itemsSchema returns the schema and type of the items of a slice nested to the given depth
+func itemsSchema(prop *spec.Schema, t types.Type, depth int) (*spec.Schema, types.Type, error) {
+	for range depth {
+		var elem types.Type
+		switch ut := derefType(t).Underlying().(type) {
+		case *types.Slice:
+			elem = ut.Elem()
+		case *types.Array:
+			elem = ut.Elem()
+		default:
+			return nil, nil, fmt.Errorf("the type %s is not a slice", t)
+		}
+		if prop.Items == nil || prop.Items.Schema == nil {
+			return nil, nil, fmt.Errorf("the type %s is rendered as a reference - annotate the type instead", t)
 		}
+		prop, t = prop.Items.Schema, elem
+	}
+	return prop, t, nil
+}
 
-		defaultMatch := schemaDefaultExp.FindStringSubmatch(c.Text)
-		if defaultMatch != nil {
-			prop = prop.WithDefault(defaultMatch[2])
+// numericValue parses the value of a numeric directive checking it against the Go type of the
+// field, e.g., the value of a directive on an uint8 field must be an integer between 0 and 255
+func numericValue(t types.Type, value string) (float64, error) {
+	basic := basicType(t)
+	if basic == nil || basic.Info()&types.IsNumeric == 0 || basic.Info()&types.IsComplex != 0 {
+		return 0, fmt.Errorf("the type %s is not numeric", t)
+	}
+
+	if basic.Info()&types.IsFloat != 0 {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s is not a number", value)
 		}
+		return v, nil
 	}
 
-	return prop
+	bits := map[types.BasicKind]int{
+		types.Int8: 8, types.Int16: 16, types.Int32: 32, types.Uint8: 8, types.Uint16: 16, types.Uint32: 32,
+	}[basic.Kind()]
+	if basic.Info()&types.IsUnsigned != 0 {
+		v, err := strconv.ParseUint(value, 10, cmp.Or(bits, 64))
+		if err != nil {
+			return 0, fmt.Errorf("%s is not a valid value of type %s", value, t)
+		}
+		return float64(v), nil
+	}
+	v, err := strconv.ParseInt(value, 10, cmp.Or(bits, 64))
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid value of type %s", value, t)
+	}
+	return float64(v), nil
 }
 
-func findTypeSpec(p *packages.Package, fieldName string) *ast.TypeSpec {
-	for _, af := range p.Syntax {
-		for _, de := range af.Decls {
-			if gd, ok := de.(*ast.GenDecl); ok {
-				for _, spec := range gd.Specs {
-					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
-						if typeSpec.Name.Name == fieldName {
-							return typeSpec
-						}
-					}
+// lengthValue parses the value of a string length directive checking that the field is a string
+func lengthValue(t types.Type, value string) (int64, error) {
+	if b := basicType(t); b == nil || b.Info()&types.IsString == 0 {
+		return 0, fmt.Errorf("the type %s is not a string", t)
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("%s is not a non-negative integer", value)
+	}
+	return v, nil
+}
+
+// countValue parses the value of an array size directive checking that the field is a slice
+func countValue(t types.Type, value string) (int64, error) {
+	if !isSlice(t) {
+		return 0, fmt.Errorf("the type %s is not a slice", t)
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("%s is not a non-negative integer", value)
+	}
+	return v, nil
+}
+
+// basicType returns the underlying basic type of the type dereferencing pointers or nil if the
+// underlying type is not basic
+func basicType(t types.Type) *types.Basic {
+	basic, _ := derefType(t).Underlying().(*types.Basic)
+	return basic
+}
+
+// isSlice returns true if the underlying type of the type dereferencing pointers is a slice or
+// an array
+func isSlice(t types.Type) bool {
+	switch ut := derefType(t).Underlying().(type) {
+	case *types.Slice:
+		return !isBytes(ut)
+	case *types.Array:
+		return true
+	}
+	return false
+}
+
+// byteArrayFormat returns the string encoding given by schema:format byte or hex of an array of
+// bytes, or the empty string if no such encoding is given
+func byteArrayFormat(a *types.Array, doc *ast.CommentGroup) string {
+	if !isByteArray(a) {
+		return ""
+	}
+	format := ""
+	for _, d := range directives(doc, DirectiveFormat) {
+		if d.Items == 0 {
+			format = d.Args[0]
+		}
+	}
+	if format != "byte" && format != "hex" {
+		return ""
+	}
+	return format
+}
+
+// isByteArray returns true if the type is an array of bytes without custom serialization
+func isByteArray(t types.Type) bool {
+	a, ok := t.Underlying().(*types.Array)
+	return ok && isBytes(types.NewSlice(a.Elem()))
+}
+
+// byteArraySchema returns the schema of an array of bytes encoded as a string of fixed length
+// in base64, i.e., the format byte, or hex
+func byteArraySchema(a *types.Array, format string) *spec.Schema {
+	if format == "hex" {
+		return spec.StrFmtProperty(format).WithMinLength(2 * a.Len()).WithMaxLength(2 * a.Len()).WithPattern("^[0-9a-fA-F]*$")
+	}
+	n := 4 * ((a.Len() + 2) / 3) // Length of padded base64
+	return spec.StrFmtProperty(format).WithMinLength(n).WithMaxLength(n)
+}
+
+// isBytes returns true if the slice is serialized as a base64 encoded string by encoding/json,
+// i.e., the elements are bytes without custom serialization
+func isBytes(s *types.Slice) bool {
+	b, ok := s.Elem().Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Uint8 && !marshals(s.Elem(), "MarshalJSON") && !marshals(s.Elem(), "MarshalText")
+}
+
+// derefType dereferences pointers to the type
+func derefType(t types.Type) types.Type {
+	for {
+		p, ok := types.Unalias(t).(*types.Pointer)
+		if !ok {
+			return types.Unalias(t)
+		}
+		t = p.Elem()
+	}
+}
+
+// exampleValue parses the example as JSON unless the schema is a string schema, i.e., the
+// example `42` of an integer property becomes a number. Examples which cannot be parsed are
+// kept as strings and will be reported when validating the examples.
+func exampleValue(prop *spec.Schema, example string) any {
+	if prop.Type.Contains("string") {
+		return example
+	}
+	var v any
+	if err := json.Unmarshal([]byte(example), &v); err != nil {
+		return example
+	}
+	return v
+}
+
+func (sg *schemaGenerator) report(pos token.Pos, severity Severity, format string, args ...any) {
+	sg.cfg.report(Diagnostic{Pos: sg.position(pos), Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+func (sg *schemaGenerator) position(pos token.Pos) token.Position {
+	if sg.fset == nil {
+		return token.Position{}
+	}
+	return sg.fset.Position(pos)
+}
+
+// path returns the syntax nodes enclosing the position innermost first
+func (sg *schemaGenerator) path(pos token.Pos) []ast.Node {
+	i := sort.Search(len(sg.files), func(i int) bool { return sg.files[i].FileEnd >= pos })
+	if i == len(sg.files) || sg.files[i].FileStart > pos {
+		return nil
+	}
+	path, _ := astutil.PathEnclosingInterval(sg.files[i], pos, pos)
+	return path
+}
+
+// typeDoc returns the godoc of a type declaration falling back to the documentation of
+// the declaration if the type is not declared in a group
+func (sg *schemaGenerator) typeDoc(obj *types.TypeName) *ast.CommentGroup {
+	path := sg.path(obj.Pos())
+	for i, n := range path {
+		if ts, ok := n.(*ast.TypeSpec); ok {
+			if ts.Doc != nil {
+				return ts.Doc
+			}
+			if i+1 < len(path) {
+				if gd, ok := path[i+1].(*ast.GenDecl); ok {
+					return gd.Doc
 				}
 			}
+			return nil
 		}
 	}
 	return nil
 }
 
-func checkKnownTypes(t *types.TypeName) *spec.Schema {
-	if t.Pkg() != nil && t.Pkg().Path() == "time" && t.Name() == "Time" {
-		return spec.DateTimeProperty()
+// constDoc returns the godoc of a constant falling back to the line comment and to the
+// documentation of the declaration if the constant is not declared in a group
+func (sg *schemaGenerator) constDoc(c *types.Const) *ast.CommentGroup {
+	path := sg.path(c.Pos())
+	for i, n := range path {
+		if vs, ok := n.(*ast.ValueSpec); ok {
+			if vs.Doc != nil {
+				return vs.Doc
+			}
+			if vs.Comment != nil {
+				return vs.Comment
+			}
+			if i+1 < len(path) {
+				if gd, ok := path[i+1].(*ast.GenDecl); ok && !gd.Lparen.IsValid() {
+					return gd.Doc
+				}
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// fieldDoc returns the godoc of a struct field - fields declared together, e.g., `A, B string`
+// share the documentation
+func (sg *schemaGenerator) fieldDoc(v *types.Var) *ast.CommentGroup {
+	for _, n := range sg.path(v.Pos()) {
+		if f, ok := n.(*ast.Field); ok {
+			return f.Doc
+		}
 	}
 	return nil
 }
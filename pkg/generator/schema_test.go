@@ -3,10 +3,39 @@ package generator
 import (
 	"testing"
 
+	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/packages"
 )
 
+// loadTestdata loads the packages matching the pattern, e.g., a directory of test data
+func loadTestdata(t *testing.T, pattern string) []*packages.Package {
+	t.Helper()
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+	}
+	pkgs, err := packages.Load(cfg, pattern)
+	require.NoError(t, err)
+	return pkgs
+}
+
+// collector collects the diagnostics reported using WithReporter(c.report)
+type collector []Diagnostic
+
+func (c *collector) report(d Diagnostic) {
+	*c = append(*c, d)
+}
+
+// messages returns the messages of the collected diagnostics
+func (c collector) messages() []string {
+	messages := []string{}
+	for _, d := range c {
+		messages = append(messages, d.Message)
+	}
+	return messages
+}
+
 func TestGenerateSchemas(t *testing.T) {
 	cfg := &packages.Config{
 		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
@@ -14,9 +43,41 @@ func TestGenerateSchemas(t *testing.T) {
 	pkgs, err := packages.Load(cfg, "./fixture/model/...")
 	if assert.NoError(t, err) {
 		schemas := GenerateSchemas(pkgs)
-		assert.Len(t, schemas, 4)
-		assert.Len(t, schemas["Model"].Properties, 10)
+		assert.Len(t, schemas, 12)
+		assert.Len(t, schemas["Model"].Properties, 11)
 		assert.Len(t, schemas["Model"].Properties["field1"].Description, 18)
+
+		parent := schemas["Node"].Properties["parent"]
+		assert.Equal(t, "#/definitions/Node", parent.Ref.String())
+		assert.Equal(t, "#/definitions/Node", schemas["Node"].Properties["children"].Items.Schema.Ref.String())
+		parent = schemas["File"].Properties["parent"]
+		assert.Equal(t, "#/definitions/Folder", parent.Ref.String())
+		assert.Equal(t, "#/definitions/File", schemas["Folder"].Properties["files"].Items.Schema.Ref.String())
+
+		status := schemas["Status"]
+		assert.Equal(t, []any{"active", "retired", "unknown"}, status.Enum)
+		assert.Equal(t, []string{"StatusActive", "StatusRetired", "statusUnknown"}, status.Extensions["x-enum-varnames"])
+		assert.Equal(t, []string{"StatusActive is the state of models in use", "StatusRetired is the state of models no longer in use", ""}, status.Extensions["x-enum-descriptions"])
+
+		embedding := schemas["Embedding"]
+		assert.Len(t, embedding.Properties, 6)
+		assert.Equal(t, "A and B are declared together and share documentation", embedding.Properties["A"].Description)
+		assert.Equal(t, "A and B are declared together and share documentation", embedding.Properties["B"].Description)
+		assert.Equal(t, "Promoted is promoted from embedded pointer", embedding.Properties["promoted"].Description)
+		assert.Equal(t, "Shadow shadows Promoted.Shadow", embedding.Properties["shadow"].Description)
+		assert.True(t, embedding.Properties["shadow"].Type.Contains("string"))
+		named := embedding.Properties["named"]
+		assert.Equal(t, "#/definitions/Named", named.Ref.String())
+		assert.NotContains(t, embedding.Properties, "Conflict")
+		assert.Contains(t, embedding.Properties, "Tagged")
+
+		aliases := schemas["Aliases"]
+		assert.Equal(t, []string{"object"}, []string(aliases.Properties["any"].Type))
+		alias := aliases.Properties["alias"]
+		assert.Equal(t, "#/definitions/Inner", alias.Ref.String())
+		pointer := aliases.Properties["pointer"]
+		assert.Equal(t, "#/definitions/Inner", pointer.Ref.String())
+		assert.ElementsMatch(t, []string{"alias", "any"}, aliases.Required)
 		/*
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
@@ -24,3 +85,502 @@ func TestGenerateSchemas(t *testing.T) {
 		*/
 	}
 }
+
+func TestGenerateSchemasPackageErrors(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/broken")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+	assert.Len(t, schemas["Model"].Properties, 1)
+	if assert.Len(t, diagnostics, 2) {
+		assert.Contains(t, diagnostics[0].Message, "component Model may be incomplete")
+		assert.Equal(t, SeverityError, diagnostics[1].Severity)
+		assert.Contains(t, diagnostics[1].Message, "property missing is left out")
+		assert.Equal(t, 8, diagnostics[1].Pos.Line)
+	}
+}
+
+func TestGenerateSchemasValidation(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/validation")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+
+	limits := schemas["Limits"]
+	count := limits.Properties["count"]
+	assert.Equal(t, 1.0, *count.Minimum)
+	assert.Equal(t, 100.0, *count.Maximum)
+	assert.False(t, count.ExclusiveMinimum)
+	ratio := limits.Properties["ratio"]
+	assert.Equal(t, 0.0, *ratio.Minimum)
+	assert.True(t, ratio.ExclusiveMinimum)
+	assert.Equal(t, 1.0, *ratio.Maximum)
+	assert.True(t, ratio.ExclusiveMaximum)
+	assert.Equal(t, 0.01, *ratio.MultipleOf)
+	assert.Nil(t, limits.Properties["small"].Maximum)
+	assert.Nil(t, limits.Properties["whole"].Minimum)
+	assert.Nil(t, limits.Properties["name"].Minimum)
+	assert.Nil(t, limits.Properties["step"].Minimum)
+	assert.Equal(t, -10.0, *schemas["Step"].Minimum)
+
+	messages := []string{}
+	for _, d := range diagnostics {
+		assert.Equal(t, SeverityError, d.Severity)
+		messages = append(messages, d.Message)
+	}
+	assert.Equal(t, []string{
+		"directive schema:maximum is ignored: 256 is not a valid value of type uint8",
+		"directive schema:minimum is ignored: 1.5 is not a valid value of type int32",
+		"directive schema:maxLength is ignored: the type int32 is not a string",
+		"directive schema:minimum is ignored: the type string is not numeric",
+		"directive schema:multipleOf is ignored: multipleOf must be greater than 0",
+		"directive schema:minimum is ignored: the type github.com/neticdk/go-openapi/pkg/generator/testdata/validation.Step is rendered as a reference - annotate the type instead",
+		"directive schema:pattern is ignored: invalid pattern: error parsing regexp: missing closing ]: `[a-z`",
+		"directive schema:items.format is ignored: the type string is not a slice",
+		"directive schema:minItems is ignored: the type string is not a slice",
+		"directive schema:writeOnly is ignored: a property cannot be both readOnly and writeOnly",
+	}, messages)
+
+	ids := limits.Properties["ids"]
+	assert.Equal(t, int64(1), *ids.MinItems)
+	assert.Equal(t, int64(5), *ids.MaxItems)
+	assert.True(t, ids.UniqueItems)
+	assert.Equal(t, "uuid", ids.Items.Schema.Format)
+	assert.Equal(t, int64(36), *ids.Items.Schema.MaxLength)
+	assert.Equal(t, 0.0, *limits.Properties["matrix"].Items.Schema.Items.Schema.Minimum)
+
+	label := limits.Properties["label"]
+	assert.Equal(t, int64(3), *label.MinLength)
+	assert.Equal(t, int64(64), *label.MaxLength)
+	assert.Equal(t, "^[a-z]+( [a-z]+)*$", label.Pattern)
+	assert.Empty(t, limits.Properties["code"].Pattern)
+	assert.Equal(t, []string{"name", "email", "id", "kind", "level", "tags", "labels", "invalid", "step"}, schemas["Request"].Required)
+	assert.Equal(t, []string{"plain", "required", "embedded"}, schemas["Optionality"].Required)
+
+	nullability := schemas["Nullability"]
+	assert.Equal(t, true, nullability.Properties["pointer"].Extensions[extNullable])
+	assert.NotContains(t, nullability.Properties["omitted"].Extensions, extNullable)
+	assert.NotContains(t, nullability.Properties["value"].Extensions, extNullable)
+	assert.Equal(t, true, nullability.Properties["wrapped"].Extensions[extNullable])
+	assert.Equal(t, false, nullability.Properties["notNull"].Extensions[extNullable])
+	assert.Equal(t, true, schemas["Custom"].Extensions[extNullable])
+
+	account := schemas["Account"]
+	assert.True(t, account.Properties["id"].ReadOnly)
+	assert.Equal(t, true, account.Properties["password"].Extensions[extWriteOnly])
+	assert.Equal(t, []string{"password"}, account.Required)
+}
+
+func TestGenerateSchemasValidateTags(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/validation")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithValidateTags(), WithAllOptional(), WithReporter(diagnostics.report))
+
+	request := schemas["Request"]
+	assert.Equal(t, []string{"name", "id"}, request.Required)
+	assert.Equal(t, []string{"required"}, schemas["Optionality"].Required)
+	name := request.Properties["name"]
+	assert.Equal(t, int64(3), *name.MinLength)
+	assert.Equal(t, int64(64), *name.MaxLength)
+	assert.Equal(t, "email", request.Properties["email"].Format)
+	assert.Equal(t, "uuid", request.Properties["id"].Format)
+	assert.Equal(t, []any{"a", "b"}, request.Properties["kind"].Enum)
+	level := request.Properties["level"]
+	assert.Equal(t, 1.0, *level.Minimum)
+	assert.Equal(t, 10.0, *level.Maximum)
+	assert.True(t, level.ExclusiveMaximum)
+	assert.Equal(t, []any{1.0, 2.0, 3.0}, level.Enum)
+	tags := request.Properties["tags"]
+	assert.Equal(t, int64(1), *tags.MinItems)
+	assert.True(t, tags.UniqueItems)
+	assert.Nil(t, tags.Items.Schema.MinLength)
+	labels := request.Properties["labels"]
+	assert.Equal(t, int64(2), *labels.MinProperties)
+	assert.Equal(t, int64(2), *labels.MaxProperties)
+	assert.Equal(t, "uri", request.Properties["homepage"].Format)
+
+	assert.Empty(t, request.Properties["step"].Enum)
+
+	messages := diagnostics.messages()
+	assert.Contains(t, messages, "validate rule gt=1 is ignored: exclusive bounds apply only to numbers")
+	assert.Contains(t, messages, "validate rule oneof=1 2 is ignored: the type github.com/neticdk/go-openapi/pkg/generator/testdata/validation.Step is rendered as a reference - annotate the type instead")
+}
+
+func TestGenerateSchemasPolymorphism(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/polymorphism")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+
+	event := schemas["Event"]
+	assert.Equal(t, "kind", event.Discriminator)
+	assert.Equal(t, []string{"kind"}, event.Required)
+	assert.Equal(t, []any{"Created", "Deleted"}, event.Properties["kind"].Enum)
+	assert.Equal(t, "Event is something happening to an entity", event.Description)
+
+	created := schemas["Created"]
+	if assert.Len(t, created.AllOf, 2) {
+		assert.Equal(t, "#/definitions/Event", created.AllOf[0].Ref.String())
+		assert.Contains(t, created.AllOf[1].Properties, "name")
+		assert.Empty(t, created.AllOf[1].Description)
+	}
+	assert.Equal(t, "Created is the event of an entity being created", created.Description)
+	assert.Len(t, schemas["Deleted"].AllOf, 2)
+	assert.NotContains(t, schemas, "Timestamp")
+
+	shape := schemas["Shape"]
+	assert.Equal(t, "type", shape.Discriminator)
+	assert.Equal(t, []any{"Circle", "Square"}, shape.Properties["type"].Enum)
+	assert.Len(t, schemas["Circle"].AllOf, 2)
+
+	assert.Equal(t, []string{"object"}, []string(schemas["Named"].Type))
+	assert.Empty(t, schemas["Named"].Discriminator)
+	history := schemas["History"]
+	assert.Equal(t, "#/definitions/Event", history.Properties["events"].Items.Schema.Ref.String())
+	shapeProp := history.Properties["shape"]
+	assert.Equal(t, "#/definitions/Shape", shapeProp.Ref.String())
+
+	messages := diagnostics.messages()
+	assert.Equal(t, []string{
+		"implementation Timestamp of Event is left out as only structs can extend the schema of Event",
+		"type Created of openapi:oneOf does not implement Shape",
+		"openapi:oneOf of Named is ignored as OpenAPI 2.0 requires an openapi:discriminator to express polymorphism",
+	}, messages)
+}
+
+func TestGenerateSchemasGenerics(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/generics")
+
+	schemas := GenerateSchemas(pkgs)
+
+	assert.NotContains(t, schemas, "Page")
+	page := schemas["PageModel"]
+	if assert.NotNil(t, page) {
+		assert.Equal(t, "Page is a page of a list of items", page.Description)
+		assert.Equal(t, "Items of the page", page.Properties["items"].Description)
+		assert.Equal(t, "#/definitions/Model", page.Properties["items"].Items.Schema.Ref.String())
+	}
+	assert.Equal(t, []string{"string"}, []string(schemas["EnvelopeStringList"].Properties["data"].Items.Schema.Type))
+	assert.Equal(t, "#/definitions/NodeInt", schemas["NodeInt"].Properties["children"].Items.Schema.Ref.String())
+	assert.Contains(t, schemas, "EnvelopeStringModelPtrMap")
+	tree := schemas["Catalog"].Properties["tree"]
+	assert.Equal(t, "#/definitions/NodeInt", tree.Ref.String())
+
+	schemas = GenerateSchemas(pkgs, WithGenericSeparator("_"))
+	assert.Contains(t, schemas, "Page_Model")
+	assert.Contains(t, schemas, "Envelope_StringList")
+}
+
+func TestGenerateSchemasGenericsNames(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/instances")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+
+	value := schemas["EnvelopeModel"]
+	if assert.NotNil(t, value) {
+		data := value.Properties["data"]
+		assert.Equal(t, "#/definitions/Model", data.Ref.String())
+		assert.NotContains(t, data.Extensions, extNullable)
+	}
+	pointer := schemas["EnvelopeModelPtr"]
+	if assert.NotNil(t, pointer) {
+		assert.Equal(t, true, pointer.Properties["data"].Extensions[extNullable])
+	}
+	ref := schemas["Wrapped"].Properties["pointer"]
+	assert.Equal(t, "#/definitions/EnvelopeModelPtr", ref.Ref.String())
+
+	errors := []string{}
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			errors = append(errors, d.Message)
+		}
+	}
+	assert.Equal(t, []string{"component name EnvelopeModel of github.com/neticdk/go-openapi/pkg/generator/testdata/instances.Envelope[github.com/neticdk/go-openapi/pkg/generator/testdata/instances/other.Model] is already in use by another type - overwriting"}, errors)
+}
+
+func TestGenerateSchemasMarshalers(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/marshalers")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+
+	assert.Equal(t, []string{"string"}, []string(schemas["ID"].Type))
+	assert.Equal(t, "ID identifies an entity", schemas["ID"].Description)
+	assert.Empty(t, schemas["ID"].Properties)
+	assert.Equal(t, []string{"string"}, []string(schemas["Secret"].Type))
+	assert.Equal(t, []string{"object"}, []string(schemas["Raw"].Type))
+	assert.Equal(t, []string{"object"}, []string(schemas["Both"].Type))
+	assert.Equal(t, []string{"string"}, []string(schemas["Amount"].Type))
+	assert.Equal(t, "decimal", schemas["Amount"].Format)
+	point := schemas["Point"]
+	assert.Equal(t, []string{"array"}, []string(point.Type))
+	assert.Equal(t, int64(2), *point.MaxItems)
+	assert.Equal(t, "Point is a coordinate", point.Description)
+	assert.Contains(t, schemas["Broken"].Properties, "Value")
+	assert.Contains(t, schemas["NotMarshaler"].Properties, "value")
+	assert.Equal(t, []string{"string"}, []string(schemas["Untyped"].Type))
+
+	messages := diagnostics.messages()
+	assert.Equal(t, []string{
+		"type Raw implements json.Marshaler and the schema inferred from the Go type may not match its JSON - describe the JSON using openapi:schemaType or openapi:schema",
+		"type Both implements json.Marshaler and the schema inferred from the Go type may not match its JSON - describe the JSON using openapi:schemaType or openapi:schema",
+		"openapi:schema of Broken is ignored: invalid JSON schema: invalid character '}' looking for beginning of value",
+		"openapi:schemaType of Untyped is ignored: date is not a JSON type - expected one of string, number, integer, boolean, object, array",
+	}, messages)
+}
+
+func TestGenerateSchemasKnownTypes(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/knowntypes")
+
+	schemas := GenerateSchemas(pkgs)
+
+	known := schemas["Known"]
+	assert.Len(t, schemas, 2)
+	assert.Equal(t, []string{"integer"}, []string(known.Properties["timeout"].Type))
+	assert.Equal(t, "int64", known.Properties["timeout"].Format)
+	if assert.Contains(t, known.Properties, "payload") {
+		assert.Empty(t, known.Properties["payload"].Type)
+	}
+	assert.Equal(t, "uri", known.Properties["link"].Format)
+	assert.Equal(t, []string{"string"}, []string(known.Properties["address"].Type))
+	assert.Equal(t, []string{"string"}, []string(known.Properties["addr"].Type))
+	assert.Equal(t, []string{"string"}, []string(known.Properties["network"].Type))
+	assert.Equal(t, []string{"integer"}, []string(known.Properties["total"].Type))
+	assert.Equal(t, []string{"string"}, []string(known.Properties["data"].Type))
+	assert.Equal(t, "byte", known.Properties["data"].Format)
+	assert.Equal(t, "byte", schemas["Blob"].Format)
+	assert.Equal(t, "byte", known.Properties["chunks"].Items.Schema.Format)
+}
+
+func TestGenerateSchemasTypeMappings(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/typemappings")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs,
+		WithTypeMappings(map[string]*spec.Schema{"time.Time": spec.Int64Property()}),
+		WithReporter(diagnostics.report))
+
+	shipment := schemas["Shipment"]
+	id := shipment.Properties["id"]
+	assert.Equal(t, []string{"string"}, []string(id.Type))
+	assert.Equal(t, "ulid", id.Format)
+	assert.Equal(t, "ID of the shipment", id.Description)
+	assert.Equal(t, []string{"array"}, []string(shipment.Properties["origin"].Type))
+	assert.Equal(t, []string{"array"}, []string(shipment.Properties["stops"].Items.Schema.Type))
+	price := shipment.Properties["price"]
+	assert.Equal(t, "#/definitions/Amount", price.Ref.String())
+	assert.Equal(t, "int64", shipment.Properties["delivered"].Format)
+
+	point := schemas["Point"]
+	assert.Equal(t, int64(2), *point.MaxItems)
+	assert.Equal(t, "Point is a coordinate", point.Description)
+
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, "type mapping of github.com/neticdk/go-openapi/pkg/generator/testdata/typemappings.Amount is ignored: text is not a JSON type - expected one of string, number, integer, boolean, object, array", diagnostics[0].Message)
+	}
+}
+
+func TestParseTypeMapping(t *testing.T) {
+	schema, err := ParseTypeMapping("string/uuid")
+	if assert.NoError(t, err) {
+		assert.Equal(t, spec.StringProperty().Type, schema.Type)
+		assert.Equal(t, "uuid", schema.Format)
+	}
+	schema, err = ParseTypeMapping(`{"type": "integer", "minimum": 0}`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0.0, *schema.Minimum)
+	}
+	_, err = ParseTypeMapping("{")
+	assert.Error(t, err)
+	_, err = ParseTypeMapping("date")
+	assert.Error(t, err)
+}
+
+func TestGenerateSchemasNumbers(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/numbers")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+
+	numbers := schemas["Numbers"]
+	assert.Nil(t, numbers.Properties["int"].Minimum)
+	assert.Equal(t, "int64", numbers.Properties["int"].Format)
+	assert.Equal(t, "int64", numbers.Properties["int64"].Format)
+	assert.Equal(t, "int32", numbers.Properties["rune"].Format)
+	for name, format := range map[string]string{"uint": "", "uint8": "int16", "byte": "int16", "uint16": "int32", "uint32": "int64", "uint64": "", "uintptr": ""} {
+		prop := numbers.Properties[name]
+		assert.Equal(t, []string{"integer"}, []string(prop.Type), name)
+		assert.Equal(t, format, prop.Format, name)
+		if assert.NotNil(t, prop.Minimum, name) {
+			assert.Equal(t, 0.0, *prop.Minimum, name)
+		}
+		if format == "" {
+			assert.Equal(t, name, prop.Extensions[extGoType], name)
+		} else {
+			assert.NotContains(t, prop.Extensions, extGoType, name)
+		}
+	}
+	assert.NotContains(t, numbers.Properties, "complex")
+	assert.NotContains(t, numbers.Properties, "pointer")
+
+	messages := diagnostics.messages()
+	assert.Equal(t, []string{
+		"property complex is left out as the type complex128 cannot be rendered",
+		"property pointer is left out as the type unsafe.Pointer cannot be rendered",
+	}, messages)
+}
+
+func TestGenerateSchemasJSONOptions(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/jsonoptions")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+
+	options := schemas["Options"]
+	count := options.Properties["count"]
+	assert.Equal(t, []string{"string"}, []string(count.Type))
+	assert.Empty(t, count.Format)
+	assert.Equal(t, "42", count.Example)
+	assert.Equal(t, "Count of things", count.Description)
+	assert.Equal(t, []string{"string"}, []string(options.Properties["enabled"].Type))
+	assert.Equal(t, []string{"string"}, []string(options.Properties["ratio"].Type))
+	assert.Equal(t, []string{"string"}, []string(options.Properties["name"].Type))
+	level := options.Properties["level"]
+	assert.Empty(t, level.Ref.String())
+	assert.Equal(t, []any{"1", "2"}, level.Enum)
+
+	assert.Contains(t, options.Properties, "created")
+	assert.Contains(t, options.Properties, "author")
+	assert.NotContains(t, options.Properties, "Audit")
+	assert.NotContains(t, options.Properties, "Extra")
+	if assert.NotNil(t, options.AdditionalProperties) {
+		assert.Equal(t, []string{"integer"}, []string(options.AdditionalProperties.Schema.Type))
+	}
+
+	assert.Equal(t, []string{"number"}, []string(options.Properties["time"].Type))
+	assert.Equal(t, "date", options.Properties["date"].Format)
+	assert.Equal(t, []string{"string"}, []string(options.Properties["timeout"].Type))
+	assert.Empty(t, options.Properties["data"].Format)
+	assert.Equal(t, "date-time", options.Properties["unknown"].Format)
+	assert.Equal(t, []string{"number"}, []string(options.Properties["millis"].Type))
+	assert.Equal(t, "double", options.Properties["millis"].Format)
+	assert.Equal(t, []string{"integer"}, []string(options.Properties["nanos"].Type))
+	assert.Equal(t, "int64", options.Properties["nanos"].Format)
+	assert.Equal(t, []string{"number"}, []string(options.Properties["delay"].Type))
+
+	assert.Equal(t, []string{"count", "ratio", "name", "level", "created", "author", "window", "time", "date", "timeout", "data", "unknown", "millis", "nanos", "delay"}, options.Required)
+
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, "json format kitchen of property unknown is not known for the type time.Time and is ignored", diagnostics[0].Message)
+	}
+}
+
+func TestGenerateSchemasAnonymousStructs(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/anonymous")
+
+	sources := SourceMap{}
+	schemas := GenerateSchemas(pkgs, WithSourceMap(sources))
+
+	assert.Len(t, schemas, 1)
+	report := schemas["Report"]
+	meta := report.Properties["meta"]
+	assert.Equal(t, "Meta describes the report", meta.Description)
+	assert.Equal(t, []string{"count", "source"}, meta.Required)
+	count := meta.Properties["count"]
+	assert.Equal(t, "Count of entries", count.Description)
+	assert.Equal(t, 0.0, *count.Minimum)
+	assert.Contains(t, meta.Properties["source"].Properties, "name")
+
+	entry := report.Properties["entries"].Items.Schema
+	assert.Equal(t, []string{"key"}, entry.Required)
+	assert.Equal(t, true, entry.Properties["value"].Extensions[extNullable])
+
+	group := report.Properties["groups"].AdditionalProperties.Schema
+	assert.Contains(t, group.Properties, "members")
+	assert.Empty(t, group.Required)
+
+	assert.Equal(t, 11, sources.Position("/definitions/Report/properties/meta/properties/count").Line)
+	assert.Equal(t, 20, sources.Position("/definitions/Report/properties/entries/items/properties/value").Line)
+}
+
+func TestGenerateSchemasArrays(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/arrays")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+
+	arrays := schemas["Arrays"]
+	point := arrays.Properties["point"]
+	assert.Equal(t, []string{"array"}, []string(point.Type))
+	assert.Equal(t, int64(3), *point.MinItems)
+	assert.Equal(t, int64(3), *point.MaxItems)
+	raw := arrays.Properties["raw"]
+	assert.Equal(t, []string{"array"}, []string(raw.Type))
+	assert.Equal(t, int64(4), *raw.MaxItems)
+	assert.Equal(t, 0.0, *raw.Items.Schema.Minimum)
+	matrix := arrays.Properties["matrix"]
+	assert.Equal(t, int64(2), *matrix.Items.Schema.MaxItems)
+
+	key := schemas["Key"]
+	assert.Equal(t, []string{"string"}, []string(key.Type))
+	assert.Equal(t, "byte", key.Format)
+	assert.Equal(t, int64(24), *key.MinLength)
+	assert.Equal(t, int64(24), *key.MaxLength)
+	id := arrays.Properties["id"]
+	assert.Equal(t, []string{"array"}, []string(id.Type))
+	assert.Empty(t, id.Format)
+	assert.Equal(t, int64(8), *id.MaxItems)
+
+	digest := schemas["Digest"]
+	assert.Equal(t, "hex", digest.Format)
+	assert.Equal(t, int64(64), *digest.MinLength)
+	assert.Equal(t, "Digest is a hex encoded hash", digest.Description)
+
+	messages := diagnostics.messages()
+	assert.Equal(t, []string{"format hex is ignored as [8]byte is serialized as an array of numbers unless encoded by encoding.TextMarshaler"}, messages)
+}
+
+func TestGenerateSchemasMapKeys(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/mapkeys")
+
+	diagnostics := collector{}
+	schemas := GenerateSchemas(pkgs, WithReporter(diagnostics.report))
+
+	maps := schemas["Maps"]
+	keyType := func(prop spec.Schema) *spec.Schema {
+		key, _ := prop.Extensions[extKeyType].(*spec.Schema)
+		return key
+	}
+	assert.Nil(t, keyType(maps.Properties["names"]))
+	if counts := keyType(maps.Properties["counts"]); assert.NotNil(t, counts) {
+		assert.Equal(t, []string{"integer"}, []string(counts.Type))
+		assert.Equal(t, "int32", counts.Format)
+		assert.Equal(t, 0.0, *counts.Minimum)
+	}
+	if status := keyType(maps.Properties["byStatus"]); assert.NotNil(t, status) {
+		assert.Equal(t, []string{"string"}, []string(status.Type))
+		assert.Equal(t, []any{"active", "retired"}, status.Enum)
+	}
+	if code := keyType(maps.Properties["byCode"]); assert.NotNil(t, code) {
+		assert.Equal(t, []string{"string"}, []string(code.Type))
+	}
+	if nested := keyType(*maps.Properties["nested"].Items.Schema); assert.NotNil(t, nested) {
+		assert.Equal(t, "int64", nested.Format)
+	}
+	assert.NotContains(t, maps.Properties, "points")
+	assert.NotContains(t, maps.Properties, "flags")
+	assert.NotContains(t, maps.Properties, "deep")
+
+	messages := []string{}
+	for _, d := range diagnostics {
+		assert.Equal(t, SeverityError, d.Severity)
+		messages = append(messages, d.Message)
+	}
+	assert.Equal(t, []string{
+		"property points is left out as map keys of type github.com/neticdk/go-openapi/pkg/generator/testdata/mapkeys.Point cannot be serialized by encoding/json",
+		"property flags is left out as map keys of type bool cannot be serialized by encoding/json",
+		"property deep is left out as map keys of type float64 cannot be serialized by encoding/json",
+	}, messages)
+}
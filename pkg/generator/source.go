@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// Source is the origin in the source code of a location in the generated specification
+type Source struct {
+	Pos    token.Position
+	NoLint []string // Rules suppressed by openapi:nolint directives, the rule "all" suppresses all rules
+}
+
+// SourceMap maps JSON pointers to locations in the generated specification to their source
+type SourceMap map[string]*Source
+
+// Position returns the source position of the location given by the JSON pointer or the
+// nearest enclosing location with a known source
+func (sm SourceMap) Position(pointer string) token.Position {
+	for p := pointer; ; p = parentPointer(p) {
+		if s, ok := sm[p]; ok {
+			return s.Pos
+		}
+		if p == "" {
+			return token.Position{}
+		}
+	}
+}
+
+// Suppressed returns true if the rule is suppressed by an openapi:nolint directive for the
+// location given by the JSON pointer or any enclosing location
+func (sm SourceMap) Suppressed(pointer, rule string) bool {
+	for p := pointer; ; p = parentPointer(p) {
+		if s, ok := sm[p]; ok {
+			for _, r := range s.NoLint {
+				if r == rule || r == "all" {
+					return true
+				}
+			}
+		}
+		if p == "" {
+			return false
+		}
+	}
+}
+
+func (sm SourceMap) add(pointer string, pos token.Position, doc *ast.CommentGroup) {
+	if sm == nil {
+		return
+	}
+	s := &Source{Pos: pos}
+	for _, d := range directives(doc, DirectiveNolint) {
+		if d.Args[0] == "" {
+			s.NoLint = append(s.NoLint, "all")
+		} else {
+			s.NoLint = append(s.NoLint, strings.Fields(d.Args[0])...)
+		}
+	}
+	sm[pointer] = s
+}
+
+// Pointer builds a JSON pointer from the given reference tokens
+func Pointer(tokens ...string) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteString("/")
+		sb.WriteString(strings.ReplaceAll(strings.ReplaceAll(t, "~", "~0"), "/", "~1"))
+	}
+	return sb.String()
+}
+
+func parentPointer(pointer string) string {
+	i := strings.LastIndex(pointer, "/")
+	if i < 0 {
+		return ""
+	}
+	return pointer[:i]
+}
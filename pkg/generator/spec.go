@@ -1,7 +1,9 @@
 package generator
 
 import (
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/go-openapi/spec"
@@ -10,10 +12,17 @@ import (
 
 var (
 	stripPackageDecl = regexp.MustCompile(`(?ms:\A(Package \S+ )?([^\n]+)\n(.*)\z)`)
-	openapiInfoExp   = regexp.MustCompile(`^//openapi:info (\S+)$`)
 )
 
-func GenerateSpec(pkgs []*packages.Package) *spec.Swagger {
+// GenerateSpec generates the specification from the given packages. Examples of properties
+// and responses are validated against their schemas reporting mismatches as diagnostics.
+func GenerateSpec(pkgs []*packages.Package, opts ...Option) *spec.Swagger {
+	cfg := newConfig(opts)
+	if cfg.sources == nil { // Source positions are needed to report mismatching examples
+		cfg.sources = SourceMap{}
+		opts = append(opts, WithSourceMap(cfg.sources))
+	}
+
 	openapi := &spec.Swagger{}
 	openapi.Swagger = "2.0"
 	for _, pkg := range pkgs {
@@ -27,29 +36,50 @@ func GenerateSpec(pkgs []*packages.Package) *spec.Swagger {
 					description = strings.TrimSpace(stripMatch[3])
 				}
 
-				for _, l := range file.Doc.List {
-					infoMatch := openapiInfoExp.FindStringSubmatch(l.Text)
-					if infoMatch != nil {
-						openapi.Info = &spec.Info{InfoProps: spec.InfoProps{
-							Title:       title,
-							Description: description,
-							Version:     infoMatch[1],
-						}}
-					}
+				for _, d := range directives(file.Doc, DirectiveInfo) {
+					openapi.Info = &spec.Info{InfoProps: spec.InfoProps{
+						Title:       title,
+						Description: description,
+						Version:     d.Args[0],
+					}}
 				}
 
 			}
 		}
 	}
 
-	schemas := GenerateSchemas(pkgs)
+	opts = append(opts, withInstances(map[instanceKey]string{}))
+	schemas := GenerateSchemas(pkgs, opts...)
 	defs := spec.Definitions{}
 	for id, schema := range schemas {
 		defs[id] = *schema
 	}
 	openapi.Definitions = defs
 
-	openapi.Paths = GenerateOperations(pkgs)
+	openapi.Paths = GenerateOperations(pkgs, append(opts, withDefinitions(defs))...)
+
+	validateExamples(openapi, cfg)
 
 	return openapi
 }
+
+// Operations calls the function for each operation of the specification in a stable order
+func Operations(doc *spec.Swagger, fn func(path, method string, op *spec.Operation)) {
+	if doc.Paths == nil {
+		return
+	}
+	for _, path := range slices.Sorted(maps.Keys(doc.Paths.Paths)) {
+		pi := doc.Paths.Paths[path]
+		for _, o := range []struct {
+			method string
+			op     *spec.Operation
+		}{
+			{"get", pi.Get}, {"put", pi.Put}, {"post", pi.Post}, {"delete", pi.Delete},
+			{"options", pi.Options}, {"head", pi.Head}, {"patch", pi.Patch},
+		} {
+			if o.op != nil {
+				fn(path, o.method, o.op)
+			}
+		}
+	}
+}
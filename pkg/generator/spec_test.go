@@ -1,8 +1,10 @@
 package generator
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/tools/go/packages"
 )
@@ -17,6 +19,12 @@ func TestGenerateSpec(t *testing.T) {
 		assert.Equal(t, "1.0.0", spec.Info.Version)
 		assert.Len(t, spec.Paths.Paths, 2)
 
+		list := spec.Paths.Paths["/entities"].Get
+		if assert.Len(t, list.Parameters, 1) {
+			assert.Equal(t, "string", list.Parameters[0].Type)
+			assert.Equal(t, []any{"active", "retired", "unknown"}, list.Parameters[0].Enum)
+		}
+
 		/*
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
@@ -26,3 +34,105 @@ func TestGenerateSpec(t *testing.T) {
 
 	}
 }
+
+func TestGenerateSpecSourceMap(t *testing.T) {
+	pkgs := loadTestdata(t, "./fixture/...")
+
+	sources := SourceMap{}
+	GenerateSpec(pkgs, WithSourceMap(sources))
+
+	assert.Equal(t, 19, sources.Position("/definitions/Model/properties/field1").Line)
+	assert.Equal(t, 19, sources.Position("/definitions/Model/properties/field1/items").Line)
+	assert.Equal(t, 5, sources.Position("/definitions/Model/properties/common").Line)
+	assert.Equal(t, 11, sources.Position(Pointer("paths", "/entities", "get")).Line)
+	assert.True(t, sources.Suppressed(Pointer("paths", "/entities", "get"), "list-pagination"))
+	assert.False(t, sources.Suppressed(Pointer("paths", "/entities", "get"), "operation-tag"))
+	assert.Equal(t, 0, sources.Position("/unknown").Line)
+}
+
+func TestGenerateSpecExamples(t *testing.T) {
+	pkgs := loadTestdata(t, "./fixture/...")
+
+	diags := collector{}
+	doc := GenerateSpec(pkgs, WithReporter(diags.report))
+
+	assert.Equal(t, float64(404), doc.Definitions["Problem"].Properties["status"].Example)
+	assert.Equal(t, "mystring", doc.Definitions["Model"].Properties["field1"].Example)
+
+	messages := map[string]string{}
+	for _, d := range diags {
+		messages[d.Message] = filepath.Base(d.Pos.Filename)
+	}
+	assert.Len(t, messages, 3)
+	assert.Equal(t, "get_operation_default.json", messages["example for response default of GET /entities/{id} does not match the schema: /this is a forbidden property"])
+	assert.Equal(t, "get_operation_error.json", messages["example for response 400 of GET /entities/{id} does not match the schema: /balance is a forbidden property"])
+}
+
+func TestValidateExample(t *testing.T) {
+	root := &spec.Swagger{}
+	root.Definitions = spec.Definitions{
+		"Item": *spec.DateTimeProperty(),
+		"Model": *(&spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}).
+			SetProperty("count", *spec.Int32Property()).
+			SetProperty("items", *spec.ArrayProperty(spec.RefSchema("#/definitions/Item"))).
+			WithRequired("count"),
+	}
+
+	assert.Empty(t, validateExample(root, spec.RefSchema("#/definitions/Model"), map[string]any{"count": 1.0}))
+	assert.Equal(t, []string{"/count is required"}, validateExample(root, spec.RefSchema("#/definitions/Model"), map[string]any{}))
+	assert.Equal(t, []string{`/count must be of type integer: "string"`}, validateExample(root, spec.RefSchema("#/definitions/Model"), map[string]any{"count": "1"}))
+	assert.Equal(t, []string{`/items must be of type date-time: "now"`}, validateExample(root, spec.RefSchema("#/definitions/Model"), map[string]any{"count": 1.0, "items": []any{"now"}}))
+	assert.NotEmpty(t, validateExample(root, spec.RefSchema("#/definitions/Model"), map[string]any{"count": nil}))
+
+	count := root.Definitions["Model"].Properties["count"]
+	count.AddExtension(extNullable, true)
+	root.Definitions["Model"].Properties["count"] = count
+	strict, err := strictCopy(root, false)
+	if assert.NoError(t, err) {
+		assert.Empty(t, validateExample(strict, spec.RefSchema("#/definitions/Model"), map[string]any{"count": nil}))
+		assert.Equal(t, []string{"/other is a forbidden property"}, validateExample(strict, spec.RefSchema("#/definitions/Model"), map[string]any{"count": 1.0, "other": 1.0}))
+	}
+
+	secret := *spec.StringProperty()
+	secret.AddExtension(extWriteOnly, true)
+	model := root.Definitions["Model"]
+	model.SetProperty("secret", secret).WithRequired("count", "secret")
+	root.Definitions["Model"] = model
+	strict, err = strictCopy(root, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"/secret is required"}, validateExample(strict, spec.RefSchema("#/definitions/Model"), map[string]any{"count": 1.0}))
+	}
+	strict, err = strictCopy(root, true)
+	if assert.NoError(t, err) {
+		assert.Empty(t, validateExample(strict, spec.RefSchema("#/definitions/Model"), map[string]any{"count": 1.0}))
+		assert.Equal(t, []string{"/secret is a forbidden property"}, validateExample(strict, spec.RefSchema("#/definitions/Model"), map[string]any{"count": 1.0, "secret": "s"}))
+	}
+
+	base := (&spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}).SetProperty("kind", *spec.StringProperty()).WithRequired("kind")
+	base.Discriminator = "kind"
+	root.Definitions["Base"] = *base
+	root.Definitions["Derived"] = *spec.ComposedSchema(*spec.RefSchema("#/definitions/Base"), *(&spec.Schema{}).SetProperty("name", *spec.StringProperty()))
+	strict, err = strictCopy(root, false)
+	if assert.NoError(t, err) {
+		assert.Empty(t, validateExample(strict, spec.RefSchema("#/definitions/Derived"), map[string]any{"kind": "Derived", "name": "n"}))
+		assert.Equal(t, []string{"/kind is required"}, validateExample(strict, spec.RefSchema("#/definitions/Derived"), map[string]any{"name": "n"}))
+	}
+}
+
+func TestGenerateSpecGenerics(t *testing.T) {
+	pkgs := loadTestdata(t, "./testdata/generics")
+
+	diags := collector{}
+	doc := GenerateSpec(pkgs, WithReporter(diags.report))
+
+	list := doc.Paths.Paths["/models"].Get
+	assert.Equal(t, "#/definitions/PageModel", list.Responses.StatusCodeResponses[200].Schema.Ref.String())
+	create := doc.Paths.Paths["/models"].Post
+	if assert.Len(t, create.Parameters, 1) {
+		assert.Equal(t, "#/definitions/EnvelopeModel", create.Parameters[0].Schema.Ref.String())
+	}
+	assert.Contains(t, doc.Definitions, "EnvelopeModel")
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Message, "type Envelope[Unknown] cannot be resolved")
+	}
+}
@@ -0,0 +1,26 @@
+package anonymous
+
+// Report has fields of anonymous struct types
+//
+//openapi:component schema Report
+type Report struct {
+	// Meta describes the report
+	Meta struct {
+		// Count of entries
+		//schema:minimum 0
+		Count int `json:"count"`
+
+		Source struct {
+			Name string `json:"name"`
+		} `json:"source"`
+	} `json:"meta"`
+
+	Entries []struct {
+		Key   string `json:"key"`
+		Value *int   `json:"value"`
+	} `json:"entries"`
+
+	Groups map[string]*struct {
+		Members []string `json:"members,omitempty"`
+	} `json:"groups"`
+}
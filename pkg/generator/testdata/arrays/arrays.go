@@ -0,0 +1,39 @@
+package arrays
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Digest is a hex encoded hash
+//
+//schema:format hex
+type Digest [32]byte
+
+func (d Digest) MarshalText() ([]byte, error) { return []byte(hex.EncodeToString(d[:])), nil }
+
+// Key is a base64 encoded key
+//
+//schema:format byte
+type Key [16]byte
+
+func (k Key) MarshalText() ([]byte, error) {
+	return []byte(base64.StdEncoding.EncodeToString(k[:])), nil
+}
+
+// Arrays has fields of fixed size arrays
+//
+//openapi:component schema Arrays
+type Arrays struct {
+	Point  [3]float64 `json:"point"`
+	Raw    [4]byte    `json:"raw"`
+	Matrix [2][2]int  `json:"matrix"`
+
+	Key Key `json:"key"`
+
+	// ID is serialized as an array of numbers
+	//schema:format hex
+	ID [8]byte `json:"id"`
+
+	Digest Digest `json:"digest"`
+}
@@ -0,0 +1,9 @@
+package broken
+
+// Model does not type-check
+//
+//openapi:component schema Model
+type Model struct {
+	Name    string  `json:"name"`
+	Missing Missing `json:"missing"`
+}
@@ -0,0 +1,54 @@
+package generics
+
+// Page is a page of a list of items
+//
+//openapi:component schema Page
+type Page[T any] struct {
+	// Items of the page
+	Items []T    `json:"items"`
+	Next  string `json:"next,omitempty"`
+}
+
+// Envelope wraps data with metadata
+type Envelope[T any] struct {
+	Data T      `json:"data"`
+	Meta string `json:"meta"`
+}
+
+// Node is a node of a tree
+type Node[T any] struct {
+	Value    T         `json:"value"`
+	Children []Node[T] `json:"children,omitempty"`
+}
+
+// Model is the model listed
+//
+//openapi:component schema Model
+type Model struct {
+	Name string `json:"name"`
+}
+
+// Catalog uses instantiations of generic types
+//
+//openapi:component schema Catalog
+type Catalog struct {
+	Models  Page[Model]                 `json:"models"`
+	Tags    Envelope[[]string]          `json:"tags"`
+	Tree    *Node[int]                  `json:"tree,omitempty"`
+	Lookups Envelope[map[string]*Model] `json:"lookups"`
+}
+
+// ListModels lists models
+//
+//openapi:operation /models GET
+//openapi:response 200 "the models"
+//openapi:responseContent 200 application/json Page[Model]
+func ListModels() {}
+
+// CreateModel creates a model
+//
+//openapi:operation /models POST
+//openapi:requestBody application/json Envelope[Model] true "the model"
+//openapi:response 201 "created"
+//openapi:responseContent 400 application/json Envelope[Unknown]
+func CreateModel() {}
@@ -0,0 +1,30 @@
+package instances
+
+import "github.com/neticdk/go-openapi/pkg/generator/testdata/instances/other"
+
+// Envelope wraps data with metadata
+type Envelope[T any] struct {
+	Data T      `json:"data"`
+	Meta string `json:"meta"`
+}
+
+// Model is the model wrapped
+type Model struct {
+	Name string `json:"name"`
+}
+
+// Wrapped holds instantiations which differ by pointer
+//
+//openapi:component schema Wrapped
+type Wrapped struct {
+	Value   Envelope[Model]  `json:"value"`
+	Pointer Envelope[*Model] `json:"pointer"`
+}
+
+// Clashing holds instantiations with type arguments of the same name
+//
+//openapi:component schema Clashing
+type Clashing struct {
+	Local   Envelope[Model]       `json:"local"`
+	Foreign Envelope[other.Model] `json:"foreign"`
+}
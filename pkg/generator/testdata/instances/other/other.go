@@ -0,0 +1,6 @@
+package other
+
+// Model is a model of another package with the same name
+type Model struct {
+	ID int `json:"id"`
+}
@@ -0,0 +1,50 @@
+package jsonoptions
+
+import "time"
+
+// Level is a log level
+type Level int
+
+const (
+	LevelDebug Level = 1
+	LevelInfo  Level = 2
+)
+
+// Audit is embedded using the inline option of encoding/json v2
+type Audit struct {
+	Created time.Time `json:"created"`
+	Author  string    `json:"author"`
+}
+
+// Options illustrates json tag options
+//
+//openapi:component schema Options
+type Options struct {
+	// Count of things
+	//schema:example 42
+	Count   int64   `json:"count,string"`
+	Enabled *bool   `json:"enabled,string,omitempty"`
+	Ratio   float64 `json:"ratio,string"`
+	Name    string  `json:"name,string"`
+	Level   Level   `json:"level,string"`
+
+	Audit  Audit          `json:",inline"`
+	Extra  map[string]int `json:",unknown"`
+	Window Window         `json:"window,omitempty"`
+	Zero   Window         `json:"zero,omitzero"`
+
+	Time    time.Time     `json:"time,format:unix"`
+	Date    time.Time     `json:"date,format:DateOnly"`
+	Timeout time.Duration `json:"timeout,format:units"`
+	Data    []byte        `json:"data,format:base16"`
+	Unknown time.Time     `json:"unknown,format:kitchen"`
+	Millis  time.Time     `json:"millis,format:unixmilli"`
+	Nanos   time.Time     `json:"nanos,format:unixnano"`
+	Delay   time.Duration `json:"delay,format:milli"`
+}
+
+// Window is a time window
+type Window struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
@@ -0,0 +1,29 @@
+package knowntypes
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+// Blob is binary data
+type Blob []byte
+
+// Known uses well-known types
+//
+//openapi:component schema Known
+type Known struct {
+	Timeout time.Duration   `json:"timeout"`
+	Payload json.RawMessage `json:"payload"`
+	Link    url.URL         `json:"link"`
+	Address net.IP          `json:"address"`
+	Addr    netip.Addr      `json:"addr"`
+	Network netip.Prefix    `json:"network"`
+	Total   *big.Int        `json:"total"`
+	Data    []byte          `json:"data"`
+	Blob    Blob            `json:"blob"`
+	Chunks  [][]byte        `json:"chunks"`
+}
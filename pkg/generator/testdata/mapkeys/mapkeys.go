@@ -0,0 +1,35 @@
+package mapkeys
+
+import "strconv"
+
+// Status of an entity
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusRetired Status = "retired"
+)
+
+// Code is serialized as text
+type Code int
+
+func (c Code) MarshalText() ([]byte, error) { return []byte("C" + strconv.Itoa(int(c))), nil }
+
+// Point cannot be a key
+type Point struct {
+	X, Y int
+}
+
+// Maps has maps with keys of different types
+//
+//openapi:component schema Maps
+type Maps struct {
+	Names    map[string]string          `json:"names"`
+	Counts   map[uint16]int             `json:"counts"`
+	ByStatus map[Status]int             `json:"byStatus"`
+	ByCode   map[Code]string            `json:"byCode"`
+	Nested   []map[int64]bool           `json:"nested"`
+	Points   map[Point]string           `json:"points"`
+	Flags    map[bool]string            `json:"flags"`
+	Deep     map[string]map[float64]int `json:"deep"`
+}
@@ -0,0 +1,89 @@
+package marshalers
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// ID identifies an entity
+type ID struct {
+	kind string
+	n    int
+}
+
+func (id ID) MarshalText() ([]byte, error) { return []byte(id.kind + strconv.Itoa(id.n)), nil }
+
+// Secret is serialized masked
+type Secret struct {
+	value string
+}
+
+func (s *Secret) MarshalText() ([]byte, error) { return []byte("***"), nil }
+
+// Raw is serialized by custom code
+type Raw struct {
+	Parts []string
+}
+
+func (r Raw) MarshalJSON() ([]byte, error) { return json.Marshal(r.Parts) }
+
+// Both prefers MarshalJSON over MarshalText like encoding/json
+type Both struct {
+	Value int
+}
+
+func (b Both) MarshalJSON() ([]byte, error) { return json.Marshal(b.Value) }
+func (b Both) MarshalText() ([]byte, error) { return []byte(strconv.Itoa(b.Value)), nil }
+
+// Amount is a decimal amount
+//
+//openapi:schemaType string/decimal
+type Amount struct {
+	units int64
+	nanos int32
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) { return json.Marshal(strconv.FormatInt(a.units, 10)) }
+
+// Point is a coordinate
+//
+//openapi:schema {"type": "array", "items": {"type": "number"}, "minItems": 2, "maxItems": 2}
+type Point struct {
+	X, Y float64
+}
+
+func (p Point) MarshalJSON() ([]byte, error) { return json.Marshal([]float64{p.X, p.Y}) }
+
+// Broken has a malformed schema
+//
+//openapi:schema {"type": }
+type Broken struct {
+	Value string
+}
+
+// Untyped has a schema type which is not a JSON type
+//
+//openapi:schemaType date
+type Untyped string
+
+// NotMarshaler has a MarshalText method with the wrong signature
+type NotMarshaler struct {
+	Value string `json:"value"`
+}
+
+func (n NotMarshaler) MarshalText() string { return n.Value }
+
+// Entity uses types with custom serialization
+//
+//openapi:component schema Entity
+type Entity struct {
+	ID       ID           `json:"id"`
+	Secret   Secret       `json:"secret"`
+	Raw      Raw          `json:"raw"`
+	Both     Both         `json:"both"`
+	Amount   Amount       `json:"amount"`
+	Location *Point       `json:"location,omitempty"`
+	Broken   Broken       `json:"broken"`
+	Other    NotMarshaler `json:"other"`
+	Untyped  Untyped      `json:"untyped"`
+}
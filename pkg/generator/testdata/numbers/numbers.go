@@ -0,0 +1,21 @@
+package numbers
+
+import "unsafe"
+
+// Numbers has fields of the basic numeric types
+//
+//openapi:component schema Numbers
+type Numbers struct {
+	Int     int            `json:"int"`
+	Int64   int64          `json:"int64"`
+	Uint    uint           `json:"uint"`
+	Uint8   uint8          `json:"uint8"`
+	Byte    byte           `json:"byte"`
+	Rune    rune           `json:"rune"`
+	Uint16  uint16         `json:"uint16"`
+	Uint32  uint32         `json:"uint32"`
+	Uint64  uint64         `json:"uint64"`
+	Uintptr uintptr        `json:"uintptr"`
+	Complex complex128     `json:"complex"`
+	Pointer unsafe.Pointer `json:"pointer"`
+}
@@ -0,0 +1,80 @@
+package polymorphism
+
+import "time"
+
+// Event is something happening to an entity
+//
+//openapi:component schema Event
+//openapi:discriminator kind
+type Event interface {
+	EventTime() time.Time
+}
+
+// Created is the event of an entity being created
+//
+//openapi:component schema Created
+type Created struct {
+	Time time.Time `json:"time"`
+	Name string    `json:"name"`
+}
+
+func (c Created) EventTime() time.Time { return c.Time }
+
+// Deleted is the event of an entity being deleted
+//
+//openapi:component schema Deleted
+type Deleted struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+func (d *Deleted) EventTime() time.Time { return d.Time }
+
+// Timestamp is not a struct and cannot extend Event
+type Timestamp int64
+
+func (t Timestamp) EventTime() time.Time { return time.Unix(int64(t), 0) }
+
+// Shape is a geometric shape
+//
+//openapi:component schema Shape
+//openapi:discriminator type
+//openapi:oneOf Circle Square Created
+type Shape interface {
+	Area() float64
+}
+
+// Circle is a round shape
+//
+//openapi:component schema Circle
+type Circle struct {
+	Radius float64 `json:"radius"`
+}
+
+func (c Circle) Area() float64 { return 3.14 * c.Radius * c.Radius }
+
+// Square is a shape with four equal sides
+//
+//openapi:component schema Square
+type Square struct {
+	Side float64 `json:"side"`
+}
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+// Named is an interface without discriminator
+//
+//openapi:component schema Named
+//openapi:oneOf Circle
+type Named interface {
+	Name() string
+}
+
+// History is a list of events
+//
+//openapi:component schema History
+type History struct {
+	Events []Event `json:"events"`
+	Shape  Shape   `json:"shape"`
+	Named  Named   `json:"named"`
+}
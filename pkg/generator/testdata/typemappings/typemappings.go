@@ -0,0 +1,35 @@
+// Package typemappings illustrates mapping Go types to schemas
+//
+//openapi:typeMapping github.com/neticdk/go-openapi/pkg/generator/testdata/typemappings.ULID string/ulid
+//openapi:typeMapping github.com/neticdk/go-openapi/pkg/generator/testdata/typemappings.Point {"type": "array", "items": {"type": "number"}, "maxItems": 2}
+//openapi:typeMapping github.com/neticdk/go-openapi/pkg/generator/testdata/typemappings.Amount text/decimal
+package typemappings
+
+import "time"
+
+// ULID is a sortable identifier
+type ULID [16]byte
+
+// Point is a coordinate
+//
+//openapi:component schema Point
+type Point struct {
+	lat, lng float64
+}
+
+// Amount is a monetary amount
+type Amount struct {
+	Units int64 `json:"units"`
+}
+
+// Shipment uses mapped types
+//
+//openapi:component schema Shipment
+type Shipment struct {
+	// ID of the shipment
+	ID        ULID      `json:"id"`
+	Origin    Point     `json:"origin"`
+	Stops     []*Point  `json:"stops"`
+	Price     Amount    `json:"price"`
+	Delivered time.Time `json:"delivered"`
+}
@@ -0,0 +1,145 @@
+package validation
+
+import "database/sql"
+
+// Limits illustrates validation directives
+//
+//openapi:component schema Limits
+type Limits struct {
+	//schema:minimum 1
+	//schema:maximum 100
+	Count int `json:"count"`
+
+	//schema:exclusiveMinimum 0
+	//schema:exclusiveMaximum 1
+	//schema:multipleOf 0.01
+	Ratio *float64 `json:"ratio"`
+
+	//schema:maximum 256
+	Small uint8 `json:"small"`
+
+	//schema:minimum 1.5
+	//schema:maxLength 10
+	Whole int32 `json:"whole"`
+
+	//schema:minimum 1
+	Name string `json:"name"`
+
+	//schema:multipleOf 0
+	Fraction float64 `json:"fraction"`
+
+	// Constraints cannot be added to references
+	//schema:minimum 1
+	Step Step `json:"step"`
+
+	//schema:minLength 3
+	//schema:maxLength 64
+	//schema:pattern ^[a-z]+( [a-z]+)*$
+	Label *string `json:"label"`
+
+	//schema:pattern [a-z
+	Code string `json:"code"`
+
+	//schema:minItems 1
+	//schema:maxItems 5
+	//schema:uniqueItems
+	//schema:items.format uuid
+	//schema:items.maxLength 36
+	IDs []string `json:"ids"`
+
+	//schema:items.items.minimum 0
+	Matrix [][]int `json:"matrix"`
+
+	//schema:items.format uuid
+	//schema:minItems 1
+	Single string `json:"single"`
+}
+
+// Step is a defined numeric type
+//
+//schema:minimum -10
+type Step int16
+
+// Request illustrates translation of validate struct tags
+//
+//openapi:component schema Request
+type Request struct {
+	Name     string            `json:"name" validate:"required,min=3,max=64"`
+	Email    string            `json:"email" validate:"omitempty,email"`
+	ID       string            `json:"id" validate:"required,uuid4"`
+	Kind     string            `json:"kind" validate:"oneof=a b"`
+	Level    int               `json:"level" validate:"gte=1,lt=10,oneof=1 2 3"`
+	Tags     []string          `json:"tags" validate:"min=1,unique,dive,min=2"`
+	Labels   map[string]string `json:"labels" validate:"len=2"`
+	Homepage *string           `json:"homepage" validate:"url"`
+	Invalid  string            `json:"invalid" validate:"gt=1"`
+	Step     Step              `json:"step" validate:"oneof=1 2"`
+}
+
+// Optionality illustrates inference of required properties
+//
+//openapi:component schema Optionality
+type Optionality struct {
+	Plain   string  `json:"plain"`
+	Pointer *string `json:"pointer"`
+	Omitted string  `json:"omitted,omitempty"`
+	Zero    string  `json:"zero,omitzero"`
+
+	//schema:optional
+	Optional string `json:"optional"`
+
+	//schema:required
+	Required *string `json:"required,omitempty"`
+
+	*Promoted
+	Embedded
+}
+
+// Promoted is embedded through a pointer and its fields are therefore optional
+type Promoted struct {
+	Promoted string `json:"promoted"`
+}
+
+// Embedded is embedded as a value and its fields are required as if declared directly
+type Embedded struct {
+	Embedded string `json:"embedded"`
+}
+
+// Nullability illustrates nullable properties
+//
+//openapi:component schema Nullability
+type Nullability struct {
+	Pointer *string `json:"pointer"`
+	Omitted *string `json:"omitted,omitempty"`
+	Value   string  `json:"value"`
+
+	//schema:nullable
+	Wrapped sql.NullString `json:"wrapped"`
+
+	//schema:nullable false
+	NotNull *int `json:"notNull"`
+
+	Custom Custom `json:"custom"`
+}
+
+// Custom is an optional wrapper
+//
+//schema:nullable
+type Custom struct {
+	Value string `json:"value"`
+}
+
+// Account illustrates properties only used in either responses or requests
+//
+//openapi:component schema Account
+type Account struct {
+	//schema:readOnly
+	ID string `json:"id"`
+
+	//schema:writeOnly
+	Password string `json:"password"`
+
+	//schema:readOnly
+	//schema:writeOnly
+	Both string `json:"both"`
+}
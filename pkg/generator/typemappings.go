@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"golang.org/x/tools/go/packages"
+)
+
+// jsonTypes are the types of JSON schemas
+var jsonTypes = []string{"string", "number", "integer", "boolean", "object", "array"}
+
+// ParseTypeMapping parses the schema of a Go type given either as a JSON type with an optional
+// format, e.g., string/uuid, or as an inline JSON schema, e.g., {"type": "string"}
+func ParseTypeMapping(mapping string) (*spec.Schema, error) {
+	schema := &spec.Schema{}
+	if strings.HasPrefix(mapping, "{") {
+		if err := json.Unmarshal([]byte(mapping), schema); err != nil {
+			return nil, fmt.Errorf("invalid JSON schema: %w", err)
+		}
+		return schema, nil
+	}
+
+	typ, format, _ := strings.Cut(mapping, "/")
+	if !slices.Contains(jsonTypes, typ) {
+		return nil, fmt.Errorf("%s is not a JSON type - expected one of %s", typ, strings.Join(jsonTypes, ", "))
+	}
+	schema.Type = []string{typ}
+	schema.Format = format
+	return schema, nil
+}
+
+// typeMappings collects the openapi:typeMapping directives of the package documentation of the
+// packages, the mappings given by options take precedence
+func (sg *schemaGenerator) typeMappings(pkgs []*packages.Package) map[string]*spec.Schema {
+	mappings := map[string]*spec.Schema{}
+	for _, p := range pkgs {
+		for _, f := range p.Syntax {
+			if f.Doc == nil {
+				continue
+			}
+			for _, c := range f.Doc.List {
+				d, err := ParseDirective(c.Text)
+				if err != nil || d == nil || d.Name != DirectiveTypeMapping {
+					continue
+				}
+				schema, err := ParseTypeMapping(d.Args[1])
+				if err != nil {
+					sg.report(c.Pos(), SeverityError, "type mapping of %s is ignored: %s", d.Args[0], err)
+					continue
+				}
+				mappings[d.Args[0]] = schema
+			}
+		}
+	}
+	for name, schema := range sg.cfg.typeMappings {
+		mappings[name] = schema
+	}
+	return mappings
+}
+
+// predefined returns the schema of a type mapped by a type mapping or a well-known type, or nil
+// if the schema is to be generated from the type
+func (sg *schemaGenerator) predefined(obj *types.TypeName) *spec.Schema {
+	if schema := sg.mapped(obj); schema != nil {
+		return schema
+	}
+	return checkKnownTypes(obj)
+}
+
+// mapped returns a copy of the schema mapped to the type or nil if the type is not mapped
+func (sg *schemaGenerator) mapped(obj *types.TypeName) *spec.Schema {
+	if obj.Pkg() == nil {
+		return nil
+	}
+	schema, ok := sg.mappings[obj.Pkg().Path()+"."+obj.Name()]
+	if !ok {
+		return nil
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil
+	}
+	clone := &spec.Schema{}
+	if err := json.Unmarshal(b, clone); err != nil {
+		return nil
+	}
+	return clone
+}
@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// validateFormats maps go-playground/validator rules to JSON schema formats
+var validateFormats = map[string]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"uuid_rfc": "uuid",
+	"uri":      "uri",
+	"url":      "uri",
+	"http_url": "uri",
+}
+
+// validateBounds maps go-playground/validator rules on numbers to numeric directives
+var validateBounds = map[string]string{
+	"min": DirectiveMinimum,
+	"gte": DirectiveMinimum,
+	"max": DirectiveMaximum,
+	"lte": DirectiveMaximum,
+	"gt":  DirectiveExclusiveMinimum,
+	"lt":  DirectiveExclusiveMaximum,
+}
+
+// validateTag translates the go-playground/validator rules of the validate struct tag to the
+// corresponding JSON schema keywords of the schema of a field of the given type. It returns
+// true if the field is required. Rules without a JSON schema counterpart are ignored, as are
+// rules following dive as they apply to the elements of the field.
+func (sg *schemaGenerator) validateTag(prop *spec.Schema, t types.Type, tag string, pos token.Pos) bool {
+	required := false
+	for _, rule := range strings.Split(reflect.StructTag(tag).Get("validate"), ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		if name == "dive" {
+			break
+		}
+		if strings.Contains(rule, "|") { // Alternatives cannot be expressed
+			continue
+		}
+
+		var err error
+		if name == "unique" || name == "oneof" || name == "len" || validateFormats[name] != "" || validateBounds[name] != "" {
+			err = referenceError(prop, t)
+		}
+		switch {
+		case err != nil:
+		case name == "required":
+			required = true
+		case name == "unique":
+			err = schemaDirectives[DirectiveUniqueItems](prop, t, "")
+		case name == "oneof":
+			err = validateEnum(prop, t, strings.Fields(arg))
+		case validateFormats[name] != "":
+			prop.Format = validateFormats[name]
+		case name == "len" || validateBounds[name] != "":
+			err = validateSize(prop, t, name, arg)
+		}
+		if err != nil {
+			sg.report(pos, SeverityError, "validate rule %s is ignored: %s", rule, err)
+		}
+	}
+	return required
+}
+
+// validateSize applies the size rules to the value of numbers, the length of strings, the number
+// of items of slices and arrays and the number of properties of maps
+func validateSize(prop *spec.Schema, t types.Type, name, arg string) error {
+	lower := name == "min" || name == "gte" || name == "len"
+	upper := name == "max" || name == "lte" || name == "len"
+
+	if b := basicType(t); b != nil && b.Info()&types.IsNumeric != 0 {
+		if name == "len" {
+			return errors.Join(schemaDirectives[DirectiveMinimum](prop, t, arg), schemaDirectives[DirectiveMaximum](prop, t, arg))
+		}
+		return schemaDirectives[validateBounds[name]](prop, t, arg)
+	}
+	if !lower && !upper {
+		return fmt.Errorf("exclusive bounds apply only to numbers")
+	}
+
+	if b := basicType(t); b != nil && b.Info()&types.IsString != 0 {
+		var err error
+		if lower {
+			err = schemaDirectives[DirectiveMinLength](prop, t, arg)
+		}
+		if upper {
+			err = errors.Join(err, schemaDirectives[DirectiveMaxLength](prop, t, arg))
+		}
+		return err
+	}
+
+	if isSlice(t) {
+		var err error
+		if lower {
+			err = schemaDirectives[DirectiveMinItems](prop, t, arg)
+		}
+		if upper {
+			err = errors.Join(err, schemaDirectives[DirectiveMaxItems](prop, t, arg))
+		}
+		return err
+	}
+
+	v, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || v < 0 {
+		return fmt.Errorf("%s is not a non-negative integer", arg)
+	}
+	switch derefType(t).Underlying().(type) {
+	case *types.Map:
+		if lower {
+			prop.WithMinProperties(v)
+		}
+		if upper {
+			prop.WithMaxProperties(v)
+		}
+	default:
+		return fmt.Errorf("the type %s has no size", t)
+	}
+	return nil
+}
+
+// validateEnum sets the values of the oneof rule as the enum of the schema
+func validateEnum(prop *spec.Schema, t types.Type, values []string) error {
+	b := basicType(t)
+	if b == nil || (b.Info()&types.IsString == 0 && b.Info()&types.IsNumeric == 0) {
+		return fmt.Errorf("the type %s is neither a string nor numeric", t)
+	}
+	enum := []any{}
+	for _, v := range values {
+		if b.Info()&types.IsString != 0 {
+			enum = append(enum, v)
+			continue
+		}
+		n, err := numericValue(t, v)
+		if err != nil {
+			return err
+		}
+		enum = append(enum, n)
+	}
+	prop.WithEnum(enum...)
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package lint checks OpenAPI specification documents against API style rules
+package lint
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/go-openapi/spec"
+	"github.com/neticdk/go-openapi/pkg/generator"
+)
+
+const (
+	severityOff = "off"
+)
+
+// Rule is a style rule checked for a specification document
+type Rule struct {
+	Name        string
+	Description string
+	Severity    generator.Severity // Default severity of the rule
+	Check       func(doc *spec.Swagger, report func(pointer, format string, args ...any))
+}
+
+// Finding is a violation of a rule at the location in the specification given by the
+// JSON pointer
+type Finding struct {
+	generator.Diagnostic
+	Rule    string
+	Pointer string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s (%s)", f.Diagnostic, f.Rule)
+}
+
+// Config configures the rules
+type Config struct {
+	// Rules maps rule names to the severity `error`, `warning` or `off` overriding the
+	// default severity of the rule
+	Rules map[string]string `mapstructure:"rules"`
+}
+
+// Lint checks the specification against the built-in rules. The source map is used to
+// find the source positions of findings and to suppress findings by openapi:nolint
+// directives, it may be nil if the specification is not generated.
+func Lint(doc *spec.Swagger, sources generator.SourceMap, cfg Config) ([]Finding, error) {
+	for name := range cfg.Rules {
+		if !slices.ContainsFunc(Rules, func(r *Rule) bool { return r.Name == name }) {
+			return nil, fmt.Errorf("unknown rule %s", name)
+		}
+	}
+
+	findings := []Finding{}
+	for _, r := range Rules {
+		severity := r.Severity
+		if s, ok := cfg.Rules[r.Name]; ok {
+			switch s {
+			case severityOff:
+				continue
+			case generator.SeverityError.String():
+				severity = generator.SeverityError
+			case generator.SeverityWarning.String():
+				severity = generator.SeverityWarning
+			default:
+				return nil, fmt.Errorf("unknown severity %s for rule %s", s, r.Name)
+			}
+		}
+
+		r.Check(doc, func(pointer, format string, args ...any) {
+			if sources.Suppressed(pointer, r.Name) {
+				return
+			}
+			findings = append(findings, Finding{
+				Diagnostic: generator.Diagnostic{
+					Pos:      sources.Position(pointer),
+					Severity: severity,
+					Message:  fmt.Sprintf(format, args...),
+				},
+				Rule:    r.Name,
+				Pointer: pointer,
+			})
+		})
+	}
+
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		if c := cmp.Compare(a.Pos.Filename, b.Pos.Filename); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Pos.Line, b.Pos.Line); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Pointer, b.Pointer)
+	})
+	return findings, nil
+}
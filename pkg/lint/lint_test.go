@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/neticdk/go-openapi/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestLint(t *testing.T) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+	}
+	pkgs, err := packages.Load(cfg, "../generator/fixture/...")
+	require.NoError(t, err)
+
+	sources := generator.SourceMap{}
+	doc := generator.GenerateSpec(pkgs, generator.WithSourceMap(sources))
+
+	findings, err := Lint(doc, sources, Config{Rules: map[string]string{"property-description": "off", "operation-tag": "warning", "operation-summary": "warning"}})
+	require.NoError(t, err)
+
+	rules := map[string]int{}
+	for _, f := range findings {
+		rules[f.Rule]++
+		assert.True(t, f.Pos.IsValid())
+		assert.Equal(t, generator.SeverityWarning, f.Severity)
+	}
+	assert.Equal(t, map[string]int{"operation-tag": 2, "operation-summary": 1, "operation-id-camel-case": 3}, rules)
+
+	_, err = Lint(doc, sources, Config{Rules: map[string]string{"unknown": "off"}})
+	assert.Error(t, err)
+	_, err = Lint(doc, sources, Config{Rules: map[string]string{"operation-tag": "fatal"}})
+	assert.Error(t, err)
+}
+
+func TestRules(t *testing.T) {
+	op := spec.NewOperation("list_items").
+		RespondsWith(404, spec.NewResponse().WithDescription("not found").WithSchema(spec.StringProperty()).AddExample("application/json", "x"))
+	doc := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+			"/itemLists": {PathItemProps: spec.PathItemProps{Get: op}},
+		}},
+		Definitions: spec.Definitions{
+			"Item": *spec.MapProperty(nil).SetProperty("nested", *new(spec.Schema).SetProperty("name", *spec.StringProperty().WithDescription("name"))),
+		},
+	}}
+
+	findings, err := Lint(doc, nil, Config{})
+	require.NoError(t, err)
+	messages := []string{}
+	for _, f := range findings {
+		messages = append(messages, f.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		"operation list_items has no tags",
+		"operation list_items has no summary",
+		"operation id list_items is not camelCase",
+		"path segment itemLists of /itemLists is not kebab-case",
+		"response 404 of operation list_items does not produce application/problem+json",
+		"response 404 of operation list_items has example for application/json rather than application/problem+json",
+		"property nested of Item has no description",
+		"list operation list_items is not paginated",
+	}, messages)
+}
@@ -0,0 +1,160 @@
+package lint
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/neticdk/go-openapi/pkg/generator"
+)
+
+const problemMediaType = "application/problem+json"
+
+var (
+	camelCaseExp   = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	kebabCaseExp   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	pathParamExp   = regexp.MustCompile(`^\{[^}]+\}$`)
+	paginationArgs = []string{"limit", "offset", "page", "pageSize", "page_size", "perPage", "per_page", "cursor"}
+)
+
+// Rules is the built-in rule set
+var Rules = []*Rule{
+	{
+		Name:        "operation-tag",
+		Description: "Every operation must have at least one tag",
+		Severity:    generator.SeverityError,
+		Check: func(doc *spec.Swagger, report func(string, string, ...any)) {
+			generator.Operations(doc, func(path, method string, op *spec.Operation) {
+				if len(op.Tags) == 0 {
+					report(generator.Pointer("paths", path, method), "operation %s has no tags", op.ID)
+				}
+			})
+		},
+	},
+	{
+		Name:        "operation-summary",
+		Description: "Every operation must have a summary",
+		Severity:    generator.SeverityError,
+		Check: func(doc *spec.Swagger, report func(string, string, ...any)) {
+			generator.Operations(doc, func(path, method string, op *spec.Operation) {
+				if strings.TrimSpace(op.Summary) == "" {
+					report(generator.Pointer("paths", path, method), "operation %s has no summary", op.ID)
+				}
+			})
+		},
+	},
+	{
+		Name:        "operation-id-camel-case",
+		Description: "Operation ids must be camelCase",
+		Severity:    generator.SeverityWarning,
+		Check: func(doc *spec.Swagger, report func(string, string, ...any)) {
+			generator.Operations(doc, func(path, method string, op *spec.Operation) {
+				if !camelCaseExp.MatchString(op.ID) {
+					report(generator.Pointer("paths", path, method), "operation id %s is not camelCase", op.ID)
+				}
+			})
+		},
+	},
+	{
+		Name:        "path-kebab-case",
+		Description: "Path segments other than parameters must be kebab-case",
+		Severity:    generator.SeverityError,
+		Check: func(doc *spec.Swagger, report func(string, string, ...any)) {
+			if doc.Paths == nil {
+				return
+			}
+			for _, path := range slices.Sorted(maps.Keys(doc.Paths.Paths)) {
+				for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+					if segment == "" || pathParamExp.MatchString(segment) {
+						continue
+					}
+					if !kebabCaseExp.MatchString(segment) {
+						report(generator.Pointer("paths", path), "path segment %s of %s is not kebab-case", segment, path)
+					}
+				}
+			}
+		},
+	},
+	{
+		Name:        "problem-json",
+		Description: "Responses with 4xx and 5xx status codes must use application/problem+json",
+		Severity:    generator.SeverityError,
+		Check: func(doc *spec.Swagger, report func(string, string, ...any)) {
+			generator.Operations(doc, func(path, method string, op *spec.Operation) {
+				if op.Responses == nil {
+					return
+				}
+				produces := op.Produces
+				if len(produces) == 0 {
+					produces = doc.Produces
+				}
+				for _, code := range slices.Sorted(maps.Keys(op.Responses.StatusCodeResponses)) {
+					if code < 400 {
+						continue
+					}
+					response := op.Responses.StatusCodeResponses[code]
+					ptr := generator.Pointer("paths", path, method, "responses", strconv.Itoa(code))
+					if response.Schema != nil && !slices.Contains(produces, problemMediaType) {
+						report(ptr, "response %d of operation %s does not produce %s", code, op.ID, problemMediaType)
+					}
+					for _, mediaType := range slices.Sorted(maps.Keys(response.Examples)) {
+						if mediaType != problemMediaType {
+							report(ptr, "response %d of operation %s has example for %s rather than %s", code, op.ID, mediaType, problemMediaType)
+						}
+					}
+				}
+			})
+		},
+	},
+	{
+		Name:        "property-description",
+		Description: "Every property of a schema must have a description",
+		Severity:    generator.SeverityWarning,
+		Check: func(doc *spec.Swagger, report func(string, string, ...any)) {
+			for _, name := range slices.Sorted(maps.Keys(doc.Definitions)) {
+				schema := doc.Definitions[name]
+				properties(generator.Pointer("definitions", name), &schema, func(ptr, property string, s *spec.Schema) {
+					if strings.TrimSpace(s.Description) == "" {
+						report(ptr, "property %s of %s has no description", property, name)
+					}
+				})
+			}
+		},
+	},
+	{
+		Name:        "list-pagination",
+		Description: "Operations listing a collection must be paginated using one of the query parameters " + strings.Join(paginationArgs, ", "),
+		Severity:    generator.SeverityWarning,
+		Check: func(doc *spec.Swagger, report func(string, string, ...any)) {
+			generator.Operations(doc, func(path, method string, op *spec.Operation) {
+				segments := strings.Split(strings.Trim(path, "/"), "/")
+				if method != "get" || pathParamExp.MatchString(segments[len(segments)-1]) {
+					return
+				}
+				for _, p := range op.Parameters {
+					if p.In == "query" && slices.Contains(paginationArgs, p.Name) {
+						return
+					}
+				}
+				report(generator.Pointer("paths", path, method), "list operation %s is not paginated", op.ID)
+			})
+		},
+	},
+}
+
+// properties calls the function for each property of the schema including properties of
+// inline object schemas
+func properties(ptr string, schema *spec.Schema, fn func(ptr, property string, s *spec.Schema)) {
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		prop := schema.Properties[name]
+		propPtr := ptr + generator.Pointer("properties", name)
+		fn(propPtr, name, &prop)
+		properties(propPtr, &prop, fn)
+		if prop.Items != nil && prop.Items.Schema != nil {
+			properties(propPtr+generator.Pointer("items"), prop.Items.Schema, fn)
+		}
+	}
+}